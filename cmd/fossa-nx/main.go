@@ -10,17 +10,35 @@ import (
 	"os"
 	"runtime"
 	"runtime/pprof"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/kamalesh-seervi/fossa-nx/internal/ai"
+	"github.com/kamalesh-seervi/fossa-nx/internal/cache"
+	"github.com/kamalesh-seervi/fossa-nx/internal/enrich"
+	"github.com/kamalesh-seervi/fossa-nx/internal/export"
 	"github.com/kamalesh-seervi/fossa-nx/internal/fossa"
+	structuredlog "github.com/kamalesh-seervi/fossa-nx/internal/log"
 	"github.com/kamalesh-seervi/fossa-nx/internal/mapping"
 	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notifier"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify"
 	"github.com/kamalesh-seervi/fossa-nx/internal/notify/email"
 	"github.com/kamalesh-seervi/fossa-nx/internal/notify/github"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify/pagerduty"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify/slack"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify/teams"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify/webhook"
 	"github.com/kamalesh-seervi/fossa-nx/internal/nx"
+	"github.com/kamalesh-seervi/fossa-nx/internal/policy"
+	"github.com/kamalesh-seervi/fossa-nx/internal/remediation"
+	"github.com/kamalesh-seervi/fossa-nx/internal/report"
+	"github.com/kamalesh-seervi/fossa-nx/internal/sbom"
+	"github.com/kamalesh-seervi/fossa-nx/internal/scheduler"
+	"github.com/kamalesh-seervi/fossa-nx/internal/shard"
 	"github.com/spf13/cobra"
 )
 
@@ -30,105 +48,55 @@ var (
 	date    = "unknown"
 )
 
-// Stats for tracking execution metrics
-type Stats struct {
-	totalProjects   int32
-	successful      int32
-	failed          int32
-	vulnerabilities int32
-	totalDuration   int64 // nanoseconds
-	maxDuration     int64 // nanoseconds
-	minDuration     int64 // nanoseconds (initialized to a large value)
-	mutex           sync.Mutex
+// initializeStats resets stats for a fresh run of projectCount projects.
+func initializeStats(s *models.Stats, projectCount int) {
+	atomic.StoreInt32(&s.TotalProjects, int32(projectCount))
+	atomic.StoreInt32(&s.Successful, 0)
+	atomic.StoreInt32(&s.Failed, 0)
+	atomic.StoreInt32(&s.Vulnerabilities, 0)
+	atomic.StoreInt32(&s.InFlight, 0)
+	atomic.StoreInt64(&s.TotalDuration, 0)
+	atomic.StoreInt64(&s.MaxDuration, 0)
+	atomic.StoreInt64(&s.MinDuration, int64(time.Hour)) // Initialize to a large value
 }
 
-func (s *Stats) initialize(projectCount int) {
-	atomic.StoreInt32(&s.totalProjects, int32(projectCount))
-	atomic.StoreInt32(&s.successful, 0)
-	atomic.StoreInt32(&s.failed, 0)
-	atomic.StoreInt64(&s.totalDuration, 0)
-	atomic.StoreInt64(&s.maxDuration, 0)
-	s.mutex.Lock()
-	s.minDuration = int64(time.Hour) // Initialize to a large value
-	s.mutex.Unlock()
-}
-
-func (s *Stats) recordResult(success bool, duration time.Duration, vulnCount int) {
+// recordResult folds one project's scan result into s. Every field is
+// updated atomically since it's shared across the scheduler's scan
+// goroutines.
+func recordResult(s *models.Stats, success bool, duration time.Duration, vulnCount int) {
 	durationNanos := duration.Nanoseconds()
 
 	if success {
-		atomic.AddInt32(&s.successful, 1)
+		atomic.AddInt32(&s.Successful, 1)
 	} else {
-		atomic.AddInt32(&s.failed, 1)
+		atomic.AddInt32(&s.Failed, 1)
 	}
 
 	if vulnCount > 0 {
-		atomic.AddInt32(&s.vulnerabilities, int32(vulnCount))
+		atomic.AddInt32(&s.Vulnerabilities, int32(vulnCount))
 	}
 
-	atomic.AddInt64(&s.totalDuration, durationNanos)
+	atomic.AddInt64(&s.TotalDuration, durationNanos)
 
 	// Update max duration (atomic compare-and-swap)
 	for {
-		old := atomic.LoadInt64(&s.maxDuration)
+		old := atomic.LoadInt64(&s.MaxDuration)
 		if durationNanos <= old {
 			break
 		}
-		if atomic.CompareAndSwapInt64(&s.maxDuration, old, durationNanos) {
+		if atomic.CompareAndSwapInt64(&s.MaxDuration, old, durationNanos) {
 			break
 		}
 	}
 
-	// Update min duration (with mutex for simplicity)
-	s.mutex.Lock()
-	if durationNanos < s.minDuration {
-		s.minDuration = durationNanos
-	}
-	s.mutex.Unlock()
-}
-
-func (s *Stats) print() {
-	successful := atomic.LoadInt32(&s.successful)
-	failed := atomic.LoadInt32(&s.failed)
-	total := atomic.LoadInt32(&s.totalProjects)
-	vulnCount := atomic.LoadInt32(&s.vulnerabilities)
-	totalDuration := time.Duration(atomic.LoadInt64(&s.totalDuration))
-
-	s.mutex.Lock()
-	minDuration := time.Duration(s.minDuration)
-	s.mutex.Unlock()
-
-	maxDuration := time.Duration(atomic.LoadInt64(&s.maxDuration))
-
-	avgDuration := time.Duration(0)
-	if successful+failed > 0 {
-		avgDuration = totalDuration / time.Duration(successful+failed)
-	}
-
-	log.Printf("FOSSA Analysis Stats:")
-	log.Printf("  Total Projects: %d", total)
-	log.Printf("  Successful: %d", successful)
-	log.Printf("  Failed: %d", failed)
-	log.Printf("  Vulnerabilities Found: %d", vulnCount)
-
-	// Display duration in minutes if > 60 seconds, otherwise show in seconds
-	if avgDuration.Seconds() > 60.0 {
-		log.Printf("  Average Duration: %.2f minutes", avgDuration.Minutes())
-	} else {
-		log.Printf("  Average Duration: %.2f seconds", avgDuration.Seconds())
-	}
-
-	if successful+failed > 0 {
-		if minDuration.Seconds() > 60.0 {
-			log.Printf("  Min Duration: %.2f minutes", minDuration.Minutes())
-		} else {
-			log.Printf("  Min Duration: %.2f seconds", minDuration.Seconds())
+	// Update min duration (atomic compare-and-swap)
+	for {
+		old := atomic.LoadInt64(&s.MinDuration)
+		if durationNanos >= old {
+			break
 		}
-
-		if maxDuration.Seconds() > 60.0 {
-			log.Printf("  Max Duration: %.2f minutes", maxDuration.Minutes())
-		} else {
-			log.Printf("  Max Duration: %.2f seconds", maxDuration.Seconds())
+		if atomic.CompareAndSwapInt64(&s.MinDuration, old, durationNanos) {
+			break
 		}
 	}
 }
@@ -146,6 +114,9 @@ func main() {
 		allProjects     bool
 		includeUnmapped bool
 		projectName     string // Add specific project option
+		logFormat       string
+		logLevel        string
+		graphFile       string
 
 		// Email configuration
 		emailEnabled bool
@@ -157,21 +128,91 @@ func main() {
 		toEmails     string
 
 		// GitHub configuration
-		githubEnabled bool
-		githubToken   string
-		githubOrg     string
-		githubRepo    string
-		githubApiUrl  string
+		githubEnabled           bool
+		githubToken             string
+		githubOrg               string
+		githubRepo              string
+		githubApiUrl            string
+		githubCreateIssues      bool
+		githubDedupeKey         string
+		githubAutoCloseResolved bool
+		githubAutoFix           bool
+		githubAutoFixSeverity   string
+		githubBaseBranch        string
+
+		// AI-assisted issue triage configuration
+		aiProvider string
+		aiModel    string
+		aiAPIKey   string
+		aiEndpoint string
+		aiRegion   string
+
+		// Remediation configuration
+		batchRemediation bool
+
+		// Notifier configuration
+		notifierStoreDir string
+
+		// Generalized chat/webhook notifier configuration
+		notifyChannels      string
+		slackWebhookURL     string
+		teamsWebhookURL     string
+		webhookURL          string
+		webhookSecret       string
+		pagerdutyRoutingKey string
+		pagerdutyThreshold  int
+
+		// SBOM export configuration
+		sbomFormat    string
+		sbomOutputDir string
+
+		// CI report export configuration
+		sarifOutput  string
+		vexOutput    string
+		osvOutputDir string
+
+		// Vulnerability policy configuration
+		failOn     string
+		ignoreFile string
+
+		// Scan cache configuration
+		cacheDir   string
+		cacheTTL   time.Duration
+		noCache    bool
+		cacheStats bool
+
+		// Structured output configuration
+		outputFormat string
+		outputFile   string
+		outputSchema bool
+
+		// Distributed sharding configuration
+		shardIndex       int
+		shardTotal       int
+		shardHistoryFile string
+		shardManifest    string
+
+		// Scheduler configuration
+		daemonStateDir string
 	)
 
+	sbom.ToolVersion = version
+	export.ToolVersion = version
+
 	// Check for version flag
 	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-V") {
 		fmt.Printf("fossa-nx version %s (%s) built on %s\n", version, commit, date)
 		os.Exit(0)
 	}
 
+	// Check for --output-schema before cobra parses, same as --version above
+	if len(os.Args) > 1 && os.Args[1] == "--output-schema" {
+		os.Stdout.Write(report.SchemaJSON())
+		os.Exit(0)
+	}
+
 	// Initialize stats tracking
-	stats := &Stats{}
+	stats := &models.Stats{}
 
 	rootCmd := &cobra.Command{
 		Use:   "fossa-nx",
@@ -190,6 +231,11 @@ Examples:
   fossa-nx --project=my-app                     # Analyze a specific project
 `,
 		Run: func(cmd *cobra.Command, args []string) {
+			if outputSchema {
+				os.Stdout.Write(report.SchemaJSON())
+				return
+			}
+
 			if verboseLogging {
 				log.Println("Running FOSSA analysis on projects...")
 				if projectName != "" {
@@ -208,33 +254,7 @@ Examples:
 				}
 			}
 
-			// Parse email recipients
-			recipientList := []string{}
-			if toEmails != "" {
-				recipientList = email.ParseEmailList(toEmails)
-				if verboseLogging {
-					log.Printf("Will send notifications to %d recipients", len(recipientList))
-				}
-			}
-
-			// Setup notification services
-			emailConfig := models.EmailConfig{
-				SmtpServer:   smtpServer,
-				SmtpPort:     smtpPort,
-				SmtpUser:     smtpUser,
-				SmtpPassword: smtpPassword,
-				FromEmail:    fromEmail,
-				ToEmails:     recipientList,
-				Enabled:      emailEnabled && len(recipientList) > 0,
-			}
-
-			githubConfig := models.GitHubConfig{
-				Token:        githubToken,
-				Organization: githubOrg,
-				Repository:   githubRepo,
-				ApiUrl:       githubApiUrl,
-				Enabled:      githubEnabled && githubToken != "",
-			}
+			emailConfig, githubConfig := buildNotifyConfigs(emailEnabled, smtpServer, smtpPort, smtpUser, smtpPassword, fromEmail, toEmails, githubEnabled, githubToken, githubOrg, githubRepo, githubApiUrl, githubCreateIssues, githubDedupeKey, githubAutoCloseResolved, failOn, githubAutoFix, githubAutoFixSeverity, githubBaseBranch, verboseLogging)
 
 			var projects []string
 			startTime := time.Now()
@@ -244,6 +264,29 @@ Examples:
 				log.Fatalf("Error: --project flag cannot be used with --all, --base, or --head")
 			}
 
+			if sbomFormat != "" && !sbom.IsValidFormat(sbomFormat) {
+				log.Fatalf("Error: --sbom must be one of cyclonedx-json, cyclonedx-xml, or spdx-json (got %q)", sbomFormat)
+			}
+
+			if !report.IsValidFormat(report.Format(outputFormat)) {
+				log.Fatalf("Error: --output must be one of text, json, or ndjson (got %q)", outputFormat)
+			}
+
+			outputWriter := io.Writer(os.Stdout)
+			if outputFile != "" {
+				f, err := os.Create(outputFile)
+				if err != nil {
+					log.Fatalf("Error creating --output-file %s: %v", outputFile, err)
+				}
+				defer f.Close()
+				outputWriter = f
+			}
+
+			reporter, err := report.New(report.Format(outputFormat), outputWriter, verboseLogging)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+
 			// Handle project-specific mode
 			if projectName != "" {
 				// Check if it's a comma-separated list
@@ -369,41 +412,104 @@ Examples:
 				log.Printf("Concurrency set to number of CPUs: %d\n", maxConcurrent)
 			}
 
+			if !cmd.Flags().Changed("shard-index") {
+				if v, err := strconv.Atoi(os.Getenv("FOSSA_NX_SHARD_INDEX")); err == nil {
+					shardIndex = v
+				}
+			}
+			if !cmd.Flags().Changed("shard-total") {
+				if v, err := strconv.Atoi(os.Getenv("FOSSA_NX_SHARD_TOTAL")); err == nil {
+					shardTotal = v
+				}
+			}
+
+			if shardTotal > 1 {
+				history := shard.LoadHistory(shardHistoryFile)
+				projects = shard.Partition(projects, history, shardIndex, shardTotal)
+				log.Printf("Shard %d/%d: analyzing %d of the discovered projects", shardIndex, shardTotal, len(projects))
+			}
+
 			// Initialize stats
-			stats.initialize(len(projects))
+			initializeStats(stats, len(projects))
 
 			// Create timeout context
 			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Minute)
 			defer cancel()
 
+			var scanCache *cache.Cache
+			if !noCache {
+				scanCache = cache.New(cacheDir, cacheTTL, cache.DefaultMaxEntries)
+			}
+
 			// Process projects with optimized worker pool
 			startTime = time.Now()
-			results := processProjectsOptimized(ctx, projects, maxConcurrent, verboseLogging, stats)
+			results := processProjectsOptimized(ctx, projects, maxConcurrent, verboseLogging, stats, scanCache, reporter)
 			duration := time.Since(startTime)
 
 			// Print summary
 			log.Printf("FOSSA analysis complete in %.2f seconds", duration.Seconds())
-			stats.print()
+			reporter.RunSummary(stats)
+			if err := reporter.Close(); err != nil {
+				log.Printf("Error writing --output: %v", err)
+			}
 
-			// Send notifications if enabled
-			if emailConfig.Enabled {
-				if err := email.SendHTMLReport(results, emailConfig, verboseLogging); err != nil {
-					log.Printf("Error sending email report: %v", err)
+			if cacheStats && scanCache != nil {
+				hits, misses := scanCache.Stats()
+				log.Printf("  Cache Hits: %d", hits)
+				log.Printf("  Cache Misses: %d", misses)
+			}
+
+			// Suppress known-accepted findings before policy gating or notifications
+			if ignoreFile != "" {
+				entries, err := policy.LoadIgnoreFile(ignoreFile)
+				if err != nil {
+					log.Fatalf("Error loading --ignore-file: %v", err)
 				}
+				policy.ApplyIgnores(results, entries)
 			}
 
-			if githubConfig.Enabled {
-				if err := github.CreateIssues(results, githubConfig, verboseLogging); err != nil {
-					log.Printf("Error creating GitHub issues: %v", err)
+			// When sharding, email/GitHub/SBOM output only fire once for the
+			// whole run, via `merge-shards` - this shard just records its
+			// slice of projects and results for that later step.
+			if shardTotal > 1 {
+				if err := shard.SaveHistory(shardHistoryFile, results); err != nil {
+					log.Printf("Error saving shard timing history: %v", err)
 				}
-				// Create commit status check
-				if err := github.CreateCommitStatus(results, githubConfig, verboseLogging); err != nil {
-					log.Printf("Error creating GitHub commit status: %v", err)
+
+				manifestPath := shardManifest
+				if manifestPath == "" {
+					manifestPath = fmt.Sprintf(".fossa-nx/shard-manifest-%d.json", shardIndex)
+				}
+				if err := shard.WriteManifest(manifestPath, shard.Manifest{
+					ShardIndex: shardIndex,
+					ShardTotal: shardTotal,
+					Projects:   projects,
+					Results:    results,
+				}); err != nil {
+					log.Printf("Error writing shard manifest: %v", err)
+				} else {
+					log.Printf("Wrote shard manifest to %s", manifestPath)
+				}
+			} else {
+				channelNotifiers := buildChannelNotifiers(notifyChannels, slackWebhookURL, teamsWebhookURL, webhookURL, webhookSecret, pagerdutyRoutingKey, pagerdutyThreshold, emailConfig, verboseLogging)
+				sendNotifications(results, emailConfig, githubConfig, batchRemediation, notifierStoreDir, sbom.Format(sbomFormat), sbomOutputDir, sarifOutput, vexOutput, osvOutputDir, buildAIClient(aiProvider, aiModel, aiAPIKey, aiEndpoint, aiRegion), channelNotifiers, report.SummaryEvent(stats), verboseLogging)
+			}
+
+			// Evaluate --fail-on independently of stats.failed, so a run with
+			// only low-severity issues can still exit 0
+			if failOn != "" {
+				violated, err := policy.Evaluate(results, failOn)
+				if err != nil {
+					log.Fatalf("Error evaluating --fail-on policy: %v", err)
+				}
+				if violated {
+					log.Printf("Exiting non-zero: one or more issues violate --fail-on policy %q", failOn)
+					os.Exit(1)
 				}
 			}
 
 			// Exit with error if any projects failed
-			if stats.failed > 0 {
+			if atomic.LoadInt32(&stats.Failed) > 0 {
 				os.Exit(1)
 			}
 		},
@@ -413,6 +519,16 @@ Examples:
 				os.Setenv("FOSSA_CONFIG_PATH", configPath)
 			}
 
+			structuredlog.Configure(structuredlog.Format(logFormat), logLevel)
+
+			enrich.GitHubToken = firstNonEmpty(githubToken, os.Getenv("GITHUB_TOKEN"))
+
+			if graphFile != "" {
+				nx.SetGraphFile(graphFile)
+			}
+			nx.SetGraphCacheDir(cacheDir)
+			nx.SetGraphCacheDisabled(noCache)
+
 			// CPU profiling if requested
 			if cpuProfile != "" {
 				f, err := os.Create(cpuProfile)
@@ -455,27 +571,213 @@ Examples:
 	rootCmd.Flags().BoolVar(&includeUnmapped, "include-unmapped", false, "Include projects not defined in configuration")
 	rootCmd.Flags().StringVarP(&projectName, "project", "p", "", "Analyze a specific project by name")
 
-	// Email notification flags
-	rootCmd.Flags().BoolVar(&emailEnabled, "email", false, "Enable email notifications")
-	rootCmd.Flags().StringVar(&smtpServer, "smtp-server", "", "SMTP server for email notifications")
-	rootCmd.Flags().IntVar(&smtpPort, "smtp-port", 587, "SMTP port for email notifications")
-	rootCmd.Flags().StringVar(&smtpUser, "smtp-user", "", "SMTP username")
-	rootCmd.Flags().StringVar(&smtpPassword, "smtp-password", "", "SMTP password")
-	rootCmd.Flags().StringVar(&fromEmail, "from-email", "", "Sender email address")
-	rootCmd.Flags().StringVar(&toEmails, "to-email", "", "Recipient email addresses (comma-separated)")
-
-	// GitHub integration flags
-	rootCmd.Flags().BoolVar(&githubEnabled, "github", false, "Enable GitHub issue creation")
-	rootCmd.Flags().StringVar(&githubToken, "github-token", "", "GitHub API token")
-	rootCmd.Flags().StringVar(&githubOrg, "github-org", "", "GitHub organization")
-	rootCmd.Flags().StringVar(&githubRepo, "github-repo", "", "GitHub repository")
-	rootCmd.Flags().StringVar(&githubApiUrl, "github-api-url", "", "GitHub API URL for Enterprise instances")
+	// Email notification flags (persistent: also used by `merge-shards`)
+	rootCmd.PersistentFlags().BoolVar(&emailEnabled, "email", false, "Enable email notifications")
+	rootCmd.PersistentFlags().StringVar(&smtpServer, "smtp-server", "", "SMTP server for email notifications")
+	rootCmd.PersistentFlags().IntVar(&smtpPort, "smtp-port", 587, "SMTP port for email notifications")
+	rootCmd.PersistentFlags().StringVar(&smtpUser, "smtp-user", "", "SMTP username")
+	rootCmd.PersistentFlags().StringVar(&smtpPassword, "smtp-password", "", "SMTP password")
+	rootCmd.PersistentFlags().StringVar(&fromEmail, "from-email", "", "Sender email address")
+	rootCmd.PersistentFlags().StringVar(&toEmails, "to-email", "", "Recipient email addresses (comma-separated)")
+
+	// GitHub integration flags (persistent: also used by `merge-shards`)
+	rootCmd.PersistentFlags().BoolVar(&githubEnabled, "github", false, "Enable GitHub issue creation")
+	rootCmd.PersistentFlags().StringVar(&githubToken, "github-token", "", "GitHub API token")
+	rootCmd.PersistentFlags().StringVar(&githubOrg, "github-org", "", "GitHub organization")
+	rootCmd.PersistentFlags().StringVar(&githubRepo, "github-repo", "", "GitHub repository")
+	rootCmd.PersistentFlags().StringVar(&githubApiUrl, "github-api-url", "", "GitHub API URL for Enterprise instances")
+	rootCmd.PersistentFlags().BoolVar(&githubCreateIssues, "github-create-issues", true, "File/update a GitHub issue per vulnerability (requires --github)")
+	rootCmd.PersistentFlags().StringVar(&githubDedupeKey, "github-dedupe-key", "project+cve", "Stored in the GitHub config but not used for matching: open FOSSA issues are always matched on project plus CVE (or vulnerability name)")
+	rootCmd.PersistentFlags().BoolVar(&githubAutoCloseResolved, "github-auto-close-resolved", true, "Close open FOSSA issues whose vulnerability is no longer detected")
+	rootCmd.PersistentFlags().BoolVar(&githubAutoFix, "github-auto-fix", false, "Open a remediation PR for vulnerabilities with a known fixed version (requires --github)")
+	rootCmd.PersistentFlags().StringVar(&githubAutoFixSeverity, "github-auto-fix-severity", "", "Comma-separated policy restricting --github-auto-fix to matching issues (e.g. high,kev); empty fixes every eligible issue")
+	rootCmd.PersistentFlags().StringVar(&githubBaseBranch, "github-base-branch", "", "Base branch remediation PRs target; empty resolves it from origin/HEAD, falling back to main")
+	rootCmd.PersistentFlags().StringVar(&aiProvider, "ai-provider", "", "Draft an AI-assisted triage section on each GitHub issue using this LLM backend: openai, anthropic, bedrock, or ollama (default: disabled)")
+	rootCmd.PersistentFlags().StringVar(&aiModel, "ai-model", "", "Model identifier for --ai-provider (defaults to a reasonable model per provider)")
+	rootCmd.PersistentFlags().StringVar(&aiAPIKey, "ai-api-key", "", "API key for --ai-provider (openai/anthropic); falls back to $FOSSA_NX_AI_API_KEY")
+	rootCmd.PersistentFlags().StringVar(&aiEndpoint, "ai-endpoint", "", "Endpoint override for --ai-provider: the full request URL for openai/anthropic, or the server base URL for ollama (e.g. a local Ollama server); ignored by bedrock")
+	rootCmd.PersistentFlags().StringVar(&aiRegion, "ai-region", "", "AWS region for --ai-provider=bedrock; credentials are read from $AWS_ACCESS_KEY_ID/$AWS_SECRET_ACCESS_KEY")
+	rootCmd.PersistentFlags().BoolVar(&batchRemediation, "batch-remediation", false, "Group all dependency fixes for a scan into a single remediation PR")
+	rootCmd.PersistentFlags().StringVar(&notifierStoreDir, "notifier-store-dir", ".fossa-nx/scans", "Directory used to persist scan history for the PR comment's new-vs-existing diff")
+
+	// Generalized chat/webhook notifier flags (persistent: also used by `merge-shards`)
+	rootCmd.PersistentFlags().StringVar(&notifyChannels, "notify", "", "Comma-separated notification channels to send the run summary to (slack,teams,webhook,pagerduty)")
+	rootCmd.PersistentFlags().StringVar(&slackWebhookURL, "slack-webhook-url", "", "Slack incoming webhook URL; also read from SLACK_WEBHOOK_URL or notifiers.slack.webhookUrl in the config file")
+	rootCmd.PersistentFlags().StringVar(&teamsWebhookURL, "teams-webhook-url", "", "Microsoft Teams incoming webhook URL; also read from TEAMS_WEBHOOK_URL or notifiers.teams.webhookUrl in the config file")
+	rootCmd.PersistentFlags().StringVar(&webhookURL, "webhook-url", "", "Generic outbound webhook URL; also read from FOSSA_NX_WEBHOOK_URL or notifiers.webhook.url in the config file")
+	rootCmd.PersistentFlags().StringVar(&webhookSecret, "webhook-secret", "", "HMAC-SHA256 secret used to sign the outbound webhook payload; also read from FOSSA_NX_WEBHOOK_SECRET or notifiers.webhook.secret")
+	rootCmd.PersistentFlags().StringVar(&pagerdutyRoutingKey, "pagerduty-routing-key", "", "PagerDuty Events v2 routing key; also read from PAGERDUTY_ROUTING_KEY or notifiers.pagerduty.routingKey in the config file")
+	rootCmd.PersistentFlags().IntVar(&pagerdutyThreshold, "pagerduty-threshold", 0, "Trigger a PagerDuty incident only once the run's high/critical issue count exceeds this; also read from notifiers.pagerduty.threshold")
+
+	// SBOM export flags (persistent: also used by `merge-shards`)
+	rootCmd.PersistentFlags().StringVar(&sbomFormat, "sbom", "", "Emit a Software Bill of Materials after scanning (cyclonedx-json|cyclonedx-xml|spdx-json)")
+	rootCmd.PersistentFlags().StringVar(&sbomOutputDir, "sbom-output-dir", ".fossa-nx/sbom", "Directory SBOM documents are written to")
+	rootCmd.PersistentFlags().StringVar(&sarifOutput, "sarif", "", "Write a SARIF 2.1.0 report to this path, for upload to GitHub code scanning")
+	rootCmd.PersistentFlags().StringVar(&vexOutput, "vex", "", "Write a CycloneDX 1.5 VEX report to this path")
+	rootCmd.PersistentFlags().StringVar(&osvOutputDir, "osv", "", "Write OSV-format vulnerability reports to this directory")
+
+	// Vulnerability policy flags (persistent: also used by `merge-shards`)
+	rootCmd.PersistentFlags().StringVar(&failOn, "fail-on", "", "Comma-separated policy that fails the run, and any GitHub Check Run, on matching issues (e.g. critical,epss>=0.5,kev)")
+	rootCmd.PersistentFlags().StringVar(&ignoreFile, "ignore-file", "", "YAML file of accepted CVEs (with optional expiry) to suppress from results and policy checks")
+
+	// Structured output flags
+	rootCmd.Flags().StringVar(&outputFormat, "output", "text", "Output format for project results and the run summary (text|json|ndjson)")
+	rootCmd.Flags().StringVar(&outputFile, "output-file", "", "File to write --output to (default: stdout)")
+	rootCmd.Flags().BoolVar(&outputSchema, "output-schema", false, "Print the JSON Schema for --output=json/ndjson events and exit")
+
+	// Distributed sharding flags
+	rootCmd.Flags().IntVar(&shardIndex, "shard-index", 0, "This shard's index (0-based); also read from FOSSA_NX_SHARD_INDEX")
+	rootCmd.Flags().IntVar(&shardTotal, "shard-total", 1, "Total number of shards; also read from FOSSA_NX_SHARD_TOTAL")
+	rootCmd.Flags().StringVar(&shardHistoryFile, "shard-history-file", ".fossa-nx/shard-history.json", "Per-project timing history used to balance shards")
+	rootCmd.Flags().StringVar(&shardManifest, "shard-manifest", "", "Path to write this shard's manifest to (default: .fossa-nx/shard-manifest-<index>.json)")
+
+	// Scan cache flags (also inherited by the `cache` subcommand)
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", ".fossa-nx/cache", "Directory used to persist scan results keyed by lockfile hash")
+	rootCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 24*time.Hour, "How long a cached scan result remains valid")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Disable the scan cache and always re-scan every project")
+	rootCmd.PersistentFlags().BoolVar(&cacheStats, "cache-stats", false, "Report cache hit/miss counts alongside the scan stats")
 
 	// Persistent flags
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "Path to config file")
 	rootCmd.PersistentFlags().StringVar(&cpuProfile, "cpuprofile", "", "Write CPU profile to file")
 	rootCmd.PersistentFlags().StringVar(&memProfile, "memprofile", "", "Write memory profile to file")
 	rootCmd.PersistentFlags().BoolP("version", "V", false, "Show version information")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log output format (json|text)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Minimum log level (debug|info|warn|error)")
+	rootCmd.PersistentFlags().StringVar(&graphFile, "graph-file", "", "Path to a pre-generated `nx graph --file` JSON dump, to avoid regenerating it per job")
+
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the on-disk scan cache",
+	}
+
+	cacheWarmCmd := &cobra.Command{
+		Use:   "warm",
+		Short: "Scan every mapped project once to populate the cache",
+		Run: func(cmd *cobra.Command, args []string) {
+			projects, err := nx.GetProjects("", "", true)
+			if err != nil {
+				log.Fatalf("Error getting projects: %v", err)
+			}
+
+			var mappedProjects []string
+			for _, project := range projects {
+				if includeUnmapped || mapping.IsProjectMapped(project) {
+					mappedProjects = append(mappedProjects, project)
+				}
+			}
+
+			if maxConcurrent <= 0 {
+				maxConcurrent = runtime.NumCPU()
+			}
+
+			warmStats := &models.Stats{}
+			initializeStats(warmStats, len(mappedProjects))
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Minute)
+			defer cancel()
+
+			scanCache := cache.New(cacheDir, cacheTTL, cache.DefaultMaxEntries)
+			reporter, err := report.New(report.Format(outputFormat), os.Stdout, verboseLogging)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+
+			processProjectsOptimized(ctx, mappedProjects, maxConcurrent, verboseLogging, warmStats, scanCache, reporter)
+			reporter.RunSummary(warmStats)
+			if err := reporter.Close(); err != nil {
+				log.Printf("Error writing --output: %v", err)
+			}
+		},
+	}
+
+	cachePruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove expired entries from the scan cache",
+		Run: func(cmd *cobra.Command, args []string) {
+			scanCache := cache.New(cacheDir, cacheTTL, cache.DefaultMaxEntries)
+			removed, err := scanCache.Prune()
+			if err != nil {
+				log.Fatalf("Error pruning cache: %v", err)
+			}
+			log.Printf("Removed %d expired cache entries from %s", removed, cacheDir)
+		},
+	}
+
+	cacheCmd.AddCommand(cacheWarmCmd, cachePruneCmd)
+	rootCmd.AddCommand(cacheCmd)
+
+	mergeShardsCmd := &cobra.Command{
+		Use:   "merge-shards <manifest-file>...",
+		Short: "Combine shard-manifest.json files from a sharded run and send notifications once",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			results, err := shard.MergeManifests(args)
+			if err != nil {
+				log.Fatalf("Error merging shard manifests: %v", err)
+			}
+			log.Printf("Merged %d shard manifest(s) into %d project result(s)", len(args), len(results))
+
+			if ignoreFile != "" {
+				entries, err := policy.LoadIgnoreFile(ignoreFile)
+				if err != nil {
+					log.Fatalf("Error loading --ignore-file: %v", err)
+				}
+				policy.ApplyIgnores(results, entries)
+			}
+
+			emailConfig, githubConfig := buildNotifyConfigs(emailEnabled, smtpServer, smtpPort, smtpUser, smtpPassword, fromEmail, toEmails, githubEnabled, githubToken, githubOrg, githubRepo, githubApiUrl, githubCreateIssues, githubDedupeKey, githubAutoCloseResolved, failOn, githubAutoFix, githubAutoFixSeverity, githubBaseBranch, verboseLogging)
+			channelNotifiers := buildChannelNotifiers(notifyChannels, slackWebhookURL, teamsWebhookURL, webhookURL, webhookSecret, pagerdutyRoutingKey, pagerdutyThreshold, emailConfig, verboseLogging)
+			sendNotifications(results, emailConfig, githubConfig, batchRemediation, notifierStoreDir, sbom.Format(sbomFormat), sbomOutputDir, sarifOutput, vexOutput, osvOutputDir, buildAIClient(aiProvider, aiModel, aiAPIKey, aiEndpoint, aiRegion), channelNotifiers, report.SummaryFromResults(results), verboseLogging)
+
+			if failOn != "" {
+				violated, err := policy.Evaluate(results, failOn)
+				if err != nil {
+					log.Fatalf("Error evaluating --fail-on policy: %v", err)
+				}
+				if violated {
+					log.Printf("Exiting non-zero: one or more issues violate --fail-on policy %q", failOn)
+					os.Exit(1)
+				}
+			}
+
+			for _, result := range results {
+				if result.Error != nil {
+					os.Exit(1)
+				}
+			}
+		},
+	}
+	rootCmd.AddCommand(mergeShardsCmd)
+
+	daemonCmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run the `schedules:` entries from the config file on a loop, emailing a new/resolved digest after each one",
+		Run: func(cmd *cobra.Command, args []string) {
+			entries := mapping.GetSchedules()
+			if len(entries) == 0 {
+				log.Fatalf("No `schedules:` section configured; the daemon has nothing to run")
+			}
+
+			emailConfig, _ := buildNotifyConfigs(emailEnabled, smtpServer, smtpPort, smtpUser, smtpPassword, fromEmail, toEmails, false, "", "", "", "", false, "", false, "", false, "", "", verboseLogging)
+
+			scan := func(ctx context.Context) ([]models.Result, error) {
+				return scanAllMappedProjects(ctx, maxConcurrent, verboseLogging, includeUnmapped, cacheDir, cacheTTL, outputFormat)
+			}
+
+			sched, err := scheduler.New(entries, scheduler.NewStore(daemonStateDir), scan, emailConfig, verboseLogging)
+			if err != nil {
+				log.Fatalf("Error configuring scheduler: %v", err)
+			}
+
+			log.Printf("fossa-nx daemon starting with %d schedule(s), state dir %s", len(entries), daemonStateDir)
+			if err := sched.Run(context.Background()); err != nil {
+				log.Fatalf("Scheduler stopped: %v", err)
+			}
+		},
+	}
+	daemonCmd.Flags().StringVar(&daemonStateDir, "state-dir", scheduler.DefaultStateDir(), "Directory used to persist each schedule entry's last-run state")
+	rootCmd.AddCommand(daemonCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -483,111 +785,548 @@ Examples:
 	}
 }
 
-// Updated to return results for notifications
-func processProjectsOptimized(ctx context.Context, projects []string, workers int, verbose bool, stats *Stats) []models.Result {
-	projectCh := make(chan string, workers*2)
-	resultCh := make(chan models.Result, workers*2)
-	results := make([]models.Result, 0, len(projects))
-
-	// Use a WaitGroup to track worker completion
-	var wg sync.WaitGroup
+// scanAllMappedProjects runs one full scan of every mapped project (or
+// every project if includeUnmapped), the same scan `fossa-nx --all` would
+// run, for use by the `daemon` subcommand's recurring schedule.
+func scanAllMappedProjects(ctx context.Context, maxConcurrent int, verbose bool, includeUnmapped bool, cacheDir string, cacheTTL time.Duration, outputFormat string) ([]models.Result, error) {
+	candidateProjects, err := nx.GetProjects("", "", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover projects: %w", err)
+	}
 
-	// Spawn worker goroutines
-	for i := 0; i < workers; i++ {
-		wg.Add(1)
-		go optimizedWorker(ctx, projectCh, resultCh, &wg, verbose, i)
-	}
-
-	// Start a goroutine to close resultCh when all workers are done
-	go func() {
-		wg.Wait()
-		close(resultCh)
-	}()
-
-	// Send projects to workers
-	go func() {
-		defer close(projectCh)
-		for _, project := range projects {
-			select {
-			case projectCh <- project:
-				// Project sent successfully
-			case <-ctx.Done():
-				// Context canceled, stop sending projects
-				return
+	var projects []string
+	if includeUnmapped {
+		projects = candidateProjects
+	} else {
+		for _, project := range candidateProjects {
+			if mapping.IsProjectMapped(project) {
+				projects = append(projects, project)
 			}
 		}
-	}()
+	}
 
-	// Collect and process results as they come in
-	for result := range resultCh {
-		success := result.Error == nil
-		stats.recordResult(success, result.Duration, len(result.Issues))
+	if len(projects) == 0 {
+		return nil, nil
+	}
+
+	if maxConcurrent <= 0 {
+		maxConcurrent = runtime.NumCPU()
+	}
+
+	stats := &models.Stats{}
+	initializeStats(stats, len(projects))
+
+	scanCache := cache.New(cacheDir, cacheTTL, cache.DefaultMaxEntries)
+
+	reporter, err := report.New(report.Format(outputFormat), os.Stdout, verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reporter: %w", err)
+	}
+
+	results := processProjectsOptimized(ctx, projects, maxConcurrent, verbose, stats, scanCache, reporter)
+	reporter.RunSummary(stats)
+	if err := reporter.Close(); err != nil {
+		log.Printf("Error writing --output: %v", err)
+	}
+
+	return results, nil
+}
+
+// processProjectsOptimized fans scans out across fossa.Scheduler (bounded by
+// workers, each project isolated in its own ephemeral workspace) and folds
+// the streamed results into stats as they arrive. Projects with an unchanged,
+// unexpired scanCache entry skip the scheduler (and the FOSSA invocation)
+// entirely; scanCache may be nil to disable caching. Each completed project
+// is handed to reporter, which renders it as text or a schema-versioned
+// event depending on --output.
+func processProjectsOptimized(ctx context.Context, projects []string, workers int, verbose bool, stats *models.Stats, scanCache *cache.Cache, reporter report.Reporter) []models.Result {
+	results := make([]models.Result, 0, len(projects))
+
+	toScan := make([]string, 0, len(projects))
+	for _, project := range projects {
+		result, ok := lookupCachedResult(project, scanCache, verbose)
+		if !ok {
+			toScan = append(toScan, project)
+			continue
+		}
+
+		recordResult(stats, true, result.Duration, len(result.Issues))
 		results = append(results, result)
+		reporter.ProjectResult(result)
+		logProjectSummary(result)
+	}
 
-		if verbose || !success {
-			if success {
-				log.Printf("✓ %s (%.2fs)", result.Project, result.Duration.Seconds())
-				if len(result.Issues) > 0 {
-					log.Printf("  Found %d vulnerabilities", len(result.Issues))
-				}
-			} else {
-				log.Printf("✗ %s: %v (%.2fs)", result.Project, result.Error, result.Duration.Seconds())
-			}
+	scheduler := fossa.NewScheduler(workers)
+
+	for result := range scheduler.Run(ctx, toScan, stats) {
+		success := result.Error == nil
+
+		// Get vulnerability data and FOSSA project link for successful scans
+		if success {
+			result.Issues, result.FossaLink, result.DependencyCount = getVulnerabilities(result.Project)
+			storeCachedResult(result, scanCache, verbose)
 		}
+
+		recordResult(stats, success, result.Duration, len(result.Issues))
+		results = append(results, result)
+		reporter.ProjectResult(result)
+		logProjectSummary(result)
 	}
 
 	return results
 }
 
-func optimizedWorker(ctx context.Context, projectCh <-chan string, resultCh chan<- models.Result, wg *sync.WaitGroup, verbose bool, workerId int) {
-	defer wg.Done()
+// lookupCachedResult reports whether project has a valid scanCache entry,
+// returning it as a zero-duration, already-complete models.Result.
+func lookupCachedResult(project string, scanCache *cache.Cache, verbose bool) (models.Result, bool) {
+	if scanCache == nil {
+		return models.Result{}, false
+	}
 
-	for {
-		select {
-		case project, ok := <-projectCh:
-			if !ok {
-				// Channel closed, worker can exit
-				return
-			}
+	key, err := projectCacheKey(project)
+	if err != nil {
+		return models.Result{}, false
+	}
 
-			if verbose {
-				log.Printf("[Worker %d] Starting FOSSA analysis for %s...", workerId, project)
-			}
+	entry, ok := scanCache.Get(key)
+	if !ok {
+		return models.Result{}, false
+	}
 
-			startTime := time.Now()
-			err := fossa.RunAnalysis(project)
+	if verbose {
+		log.Printf("✓ %s (cache hit)", project)
+	}
+
+	return models.Result{
+		Project:         project,
+		Issues:          entry.Issues,
+		FossaLink:       entry.FossaLink,
+		DependencyCount: entry.DependencyCount,
+		EndTime:         time.Now(),
+	}, true
+}
+
+// storeCachedResult persists a successful scan's result so a later run with
+// unchanged lockfiles can skip re-scanning project.
+func storeCachedResult(result models.Result, scanCache *cache.Cache, verbose bool) {
+	if scanCache == nil {
+		return
+	}
+
+	key, err := projectCacheKey(result.Project)
+	if err != nil {
+		if verbose {
+			log.Printf("Not caching %s: %v", result.Project, err)
+		}
+		return
+	}
+
+	entry := cache.Entry{
+		Key:             key,
+		Project:         result.Project,
+		Issues:          result.Issues,
+		FossaLink:       result.FossaLink,
+		DependencyCount: result.DependencyCount,
+	}
+	if err := scanCache.Put(entry); err != nil && verbose {
+		log.Printf("Failed to cache result for %s: %v", result.Project, err)
+	}
+}
+
+// projectCacheKey resolves project's root and derives its cache key from the
+// lockfiles found there.
+func projectCacheKey(project string) (string, error) {
+	projectRoot, err := nx.GetProjectRoot(project)
+	if err != nil {
+		return "", err
+	}
+	return cache.ComputeKey(project, projectRoot)
+}
+
+// logProjectSummary emits a final structured record per project that
+// mirrors models.Result, so CI systems can grep/jq the log stream instead of
+// scraping human-readable text.
+func logProjectSummary(result models.Result) {
+	fields := structuredlog.Fields{
+		"project":          result.Project,
+		"duration_ms":      result.Duration.Milliseconds(),
+		"dependency_count": result.DependencyCount,
+		"vulnerabilities":  len(result.Issues),
+		"fossa_link":       result.FossaLink,
+	}
+
+	entry := structuredlog.With(fields)
+	if result.Error != nil {
+		entry.WithError(result.Error).Error("project scan summary")
+		return
+	}
+	entry.Info("project scan summary")
+}
+
+// buildNotifyConfigs parses the email/GitHub notification flags into the
+// models.EmailConfig/models.GitHubConfig that email.SendHTMLReport and the
+// github package expect. Shared by the root command and `merge-shards`.
+func buildNotifyConfigs(emailEnabled bool, smtpServer string, smtpPort int, smtpUser, smtpPassword, fromEmail, toEmails string, githubEnabled bool, githubToken, githubOrg, githubRepo, githubApiUrl string, githubCreateIssues bool, githubDedupeKey string, githubAutoCloseResolved bool, githubFailOn string, githubAutoFix bool, githubAutoFixSeverity, githubBaseBranch string, verbose bool) (models.EmailConfig, models.GitHubConfig) {
+	recipientList := []string{}
+	if toEmails != "" {
+		recipientList = email.ParseEmailList(toEmails)
+		if verbose {
+			log.Printf("Will send notifications to %d recipients", len(recipientList))
+		}
+	}
+
+	emailConfig := models.EmailConfig{
+		SmtpServer:   smtpServer,
+		SmtpPort:     smtpPort,
+		SmtpUser:     smtpUser,
+		SmtpPassword: smtpPassword,
+		FromEmail:    fromEmail,
+		ToEmails:     recipientList,
+		Enabled:      emailEnabled && len(recipientList) > 0,
+	}
+
+	githubConfig := models.GitHubConfig{
+		Token:             githubToken,
+		Organization:      githubOrg,
+		Repository:        githubRepo,
+		ApiUrl:            githubApiUrl,
+		Enabled:           githubEnabled && githubToken != "",
+		CreateIssues:      githubCreateIssues,
+		DedupeKey:         githubDedupeKey,
+		AutoCloseResolved: githubAutoCloseResolved,
+		FailOn:            githubFailOn,
+		AutoFix:           githubAutoFix,
+		AutoFixSeverity:   githubAutoFixSeverity,
+		DefaultBranch:     githubBaseBranch,
+	}
+
+	return emailConfig, githubConfig
+}
+
+// buildAIClient resolves the ai.Client selected by --ai-provider, reading
+// its API key/credentials from the matching flag or well-known env var
+// when the flag is empty. Returns ai.NoopClient{} if provider is empty.
+func buildAIClient(provider, model, apiKey, endpoint, region string) ai.Client {
+	return ai.New(ai.Config{
+		Provider:        provider,
+		Model:           model,
+		APIKey:          firstNonEmpty(apiKey, os.Getenv("FOSSA_NX_AI_API_KEY")),
+		Endpoint:        endpoint,
+		Region:          region,
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+	})
+}
+
+// buildChannelNotifiers resolves the chat/webhook notifiers selected by
+// --notify into a []notify.Notifier, falling back from the matching flag to
+// its env var to the config file's `notifiers:` section when a channel's
+// target isn't set on the command line. Shared by the root command and
+// `merge-shards`.
+func buildChannelNotifiers(notifyChannels, slackWebhookURL, teamsWebhookURL, webhookURL, webhookSecret, pagerdutyRoutingKey string, pagerdutyThreshold int, emailConfig models.EmailConfig, verbose bool) []notify.Notifier {
+	channels := notify.ParseChannels(notifyChannels)
+	configured := mapping.GetNotifiersConfig()
+
+	var notifiers []notify.Notifier
+
+	// Email is folded into this list (rather than dispatched directly) so
+	// it runs through the same parallel, severity-filterable pipeline as
+	// every other channel; its own --email flag still governs whether it's
+	// enabled at all.
+	if emailConfig.Enabled {
+		notifiers = append(notifiers, email.NewNotifier(emailConfig, verbose))
+	}
+
+	if notify.Has(channels, notify.ChannelSlack) {
+		url := firstNonEmpty(slackWebhookURL, os.Getenv("SLACK_WEBHOOK_URL"), configured.Slack.WebhookURL)
+		if url != "" {
+			notifiers = append(notifiers, withMinSeverity(slack.New(slack.Config{WebhookURL: url, Enabled: true}), configured.Slack.MinSeverity))
+		} else {
+			log.Printf("--notify=slack requested but no Slack webhook URL was configured")
+		}
+	}
+
+	if notify.Has(channels, notify.ChannelTeams) {
+		url := firstNonEmpty(teamsWebhookURL, os.Getenv("TEAMS_WEBHOOK_URL"), configured.Teams.WebhookURL)
+		if url != "" {
+			notifiers = append(notifiers, withMinSeverity(teams.New(teams.Config{WebhookURL: url, Enabled: true}), configured.Teams.MinSeverity))
+		} else {
+			log.Printf("--notify=teams requested but no Teams webhook URL was configured")
+		}
+	}
+
+	if notify.Has(channels, notify.ChannelWebhook) {
+		url := firstNonEmpty(webhookURL, os.Getenv("FOSSA_NX_WEBHOOK_URL"), configured.Webhook.URL)
+		secret := firstNonEmpty(webhookSecret, os.Getenv("FOSSA_NX_WEBHOOK_SECRET"), configured.Webhook.Secret)
+		if url != "" {
+			notifiers = append(notifiers, withMinSeverity(webhook.New(webhook.Config{URL: url, Secret: secret, Enabled: true}), configured.Webhook.MinSeverity))
+		} else {
+			log.Printf("--notify=webhook requested but no webhook URL was configured")
+		}
+	}
+
+	if notify.Has(channels, notify.ChannelPagerDuty) {
+		routingKey := firstNonEmpty(pagerdutyRoutingKey, os.Getenv("PAGERDUTY_ROUTING_KEY"), configured.PagerDuty.RoutingKey)
+		threshold := pagerdutyThreshold
+		if threshold == 0 {
+			threshold = configured.PagerDuty.Threshold
+		}
+		if routingKey != "" {
+			notifiers = append(notifiers, pagerduty.New(pagerduty.Config{RoutingKey: routingKey, Threshold: threshold, Enabled: true}))
+		} else {
+			log.Printf("--notify=pagerduty requested but no PagerDuty routing key was configured")
+		}
+	}
+
+	return notifiers
+}
+
+// severityFilteredNotifier wraps a Notifier so it only ever sees issues at
+// or above minSeverity, letting e.g. Slack post only on criticals while
+// email still gets every issue, from the same scan. PagerDuty is not
+// wrapped; it applies its own high/critical count Threshold instead.
+type severityFilteredNotifier struct {
+	inner       notify.Notifier
+	minSeverity string
+}
+
+func withMinSeverity(inner notify.Notifier, minSeverity string) notify.Notifier {
+	if minSeverity == "" {
+		return inner
+	}
+	return severityFilteredNotifier{inner: inner, minSeverity: minSeverity}
+}
+
+func (n severityFilteredNotifier) Notify(ctx context.Context, results []models.Result, summary notify.RunSummary) error {
+	return n.inner.Notify(ctx, notify.FilterBySeverity(results, n.minSeverity), summary)
+}
+
+// firstNonEmpty returns the first non-empty string in values, or "".
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// sendNotifications dispatches GitHub, remediation, SBOM, and --notify
+// chat/webhook/email output for a finished set of results. Called once per
+// run, or - for a sharded run - once from `merge-shards` against the
+// combined results of every shard, so these don't fire once per shard.
+// channelNotifiers (built by buildChannelNotifiers, and including email
+// when --email is enabled) all run concurrently, since they're independent
+// of one another and of GitHub/SBOM.
+func sendNotifications(results []models.Result, emailConfig models.EmailConfig, githubConfig models.GitHubConfig, batchRemediation bool, notifierStoreDir string, sbomFormat sbom.Format, sbomOutputDir string, sarifOutput string, vexOutput string, osvOutputDir string, aiClient ai.Client, channelNotifiers []notify.Notifier, summary notify.RunSummary, verbose bool) {
+	if githubConfig.Enabled {
+		if err := github.CreateIssues(results, githubConfig, aiClient, verbose); err != nil {
+			log.Printf("Error creating GitHub issues: %v", err)
+		}
+		// Create commit status check
+		if err := github.CreateCommitStatus(results, githubConfig, verbose); err != nil {
+			log.Printf("Error creating GitHub commit status: %v", err)
+		}
+
+		if err := runRemediation(results, githubConfig, batchRemediation, verbose); err != nil {
+			log.Printf("Error opening remediation PRs: %v", err)
+		}
+
+		checkNotifier := notifier.New(githubConfig, notifier.NewFileStore(notifierStoreDir))
+		if err := checkNotifier.Publish(context.Background(), results); err != nil {
+			log.Printf("Error publishing check runs / PR comment: %v", err)
+		}
+	}
 
-			// Get vulnerability data and FOSSA project link
-			issues := []models.VulnerabilityIssue{}
-			fossaLink := ""
-			depCount := 0
+	if sbomFormat != "" {
+		if err := exportSBOMs(results, sbomFormat, sbomOutputDir, verbose); err != nil {
+			log.Printf("Error exporting SBOM: %v", err)
+		}
+	}
 
-			// If analysis was successful, check for vulnerabilities
-			if err == nil {
-				issues, fossaLink, depCount = getVulnerabilities(project)
+	if sarifOutput != "" {
+		if err := export.WriteSARIF(sarifOutput, results); err != nil {
+			log.Printf("Error writing SARIF report: %v", err)
+		} else if verbose {
+			log.Printf("Wrote SARIF report to %s", sarifOutput)
+		}
+	}
+
+	if vexOutput != "" {
+		if err := export.WriteVEX(vexOutput, results); err != nil {
+			log.Printf("Error writing VEX report: %v", err)
+		} else if verbose {
+			log.Printf("Wrote VEX report to %s", vexOutput)
+		}
+	}
+
+	if osvOutputDir != "" {
+		if path, err := export.WriteOSV(osvOutputDir, results); err != nil {
+			log.Printf("Error writing OSV report: %v", err)
+		} else if verbose {
+			log.Printf("Wrote OSV report to %s", path)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, n := range channelNotifiers {
+		wg.Add(1)
+		go func(n notify.Notifier) {
+			defer wg.Done()
+			if err := n.Notify(context.Background(), results, summary); err != nil {
+				log.Printf("Error sending %T notification: %v", n, err)
 			}
+		}(n)
+	}
+	wg.Wait()
+}
 
-			duration := time.Since(startTime)
-			endTime := time.Now() // Record when the scan completed
+// runRemediation plans and opens dependency-bump pull requests for every
+// vulnerability that reports a fixed version and, when AutoFixSeverity is
+// set, matches that policy. It is a no-op unless GitHub integration and
+// AutoFix are both enabled.
+func runRemediation(results []models.Result, githubConfig models.GitHubConfig, batch, verbose bool) error {
+	if !githubConfig.Enabled || !githubConfig.AutoFix {
+		return nil
+	}
 
-			resultCh <- models.Result{
-				Project:         project,
-				Error:           err,
-				Duration:        duration,
-				EndTime:         endTime,
-				Issues:          issues,
-				FossaLink:       fossaLink,
-				DependencyCount: depCount,
+	planner := remediation.NewPlanner()
+	var fixes []*remediation.Fix
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+
+		for _, issue := range result.Issues {
+			if issue.FixedIn == "" {
+				continue
+			}
+
+			if githubConfig.AutoFixSeverity != "" {
+				eligible, err := policy.Evaluate([]models.Result{{Project: result.Project, Issues: []models.VulnerabilityIssue{issue}}}, githubConfig.AutoFixSeverity)
+				if err != nil {
+					log.Printf("invalid --github-auto-fix-severity policy %q: %v", githubConfig.AutoFixSeverity, err)
+				} else if !eligible {
+					continue
+				}
 			}
 
-		case <-ctx.Done():
-			// Context canceled, worker should exit
-			if verbose {
-				log.Printf("[Worker %d] Shutting down (context canceled)", workerId)
+			fix, err := planner.Plan(result.Project, issue)
+			if err != nil {
+				if verbose {
+					log.Printf("Skipping remediation for %s in %s: %v", issue.Name, result.Project, err)
+				}
+				continue
 			}
-			return
+
+			fixes = append(fixes, fix)
 		}
 	}
+
+	if len(fixes) == 0 {
+		return nil
+	}
+
+	if verbose {
+		log.Printf("Opening remediation PRs for %d vulnerable dependencies", len(fixes))
+	}
+
+	publisher := remediation.NewPublisher(githubConfig)
+	return publisher.Publish(context.Background(), fixes, batch)
+}
+
+// exportSBOMs writes a per-project SBOM for every successfully scanned
+// project, plus one merged SBOM covering every component across the
+// workspace.
+func exportSBOMs(results []models.Result, format sbom.Format, outputDir string, verbose bool) error {
+	componentsByProject := make(map[string][]sbom.Component)
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+
+		components := getComponents(result.Project)
+		componentsByProject[result.Project] = components
+
+		path, err := sbom.WriteSBOM(format, outputDir, result.Project, components)
+		if err != nil {
+			log.Printf("Error writing SBOM for %s: %v", result.Project, err)
+			continue
+		}
+
+		if verbose {
+			log.Printf("Wrote %s SBOM for %s to %s", format, result.Project, path)
+		}
+	}
+
+	merged := sbom.MergeComponents(componentsByProject)
+	path, err := sbom.WriteSBOM(format, outputDir, "workspace", merged)
+	if err != nil {
+		return fmt.Errorf("failed to write merged workspace SBOM: %w", err)
+	}
+
+	log.Printf("Wrote merged workspace %s SBOM (%d components) to %s", format, len(merged), path)
+	return nil
+}
+
+// getComponents fetches a project's resolved dependency graph from the
+// FOSSA API for SBOM generation.
+func getComponents(project string) []sbom.Component {
+	components := []sbom.Component{}
+
+	fossaProjectId := mapping.GetFossaProjectID(project)
+	fossaEndpoint := mapping.GetFossaEndpoint()
+
+	apiUrl := fmt.Sprintf("%s/api/projects/%s/dependencies", fossaEndpoint, fossaProjectId)
+	req, err := http.NewRequest("GET", apiUrl, nil)
+	if err != nil {
+		return components
+	}
+
+	apiKey := os.Getenv("FOSSA_API_KEY")
+	req.Header.Add("Authorization", "Bearer "+apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return components
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return components
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return components
+	}
+
+	var dependenciesResponse struct {
+		Dependencies []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			PURL    string `json:"purl"`
+			License string `json:"license"`
+		} `json:"dependencies"`
+	}
+
+	if err := json.Unmarshal(body, &dependenciesResponse); err != nil {
+		return components
+	}
+
+	for _, dep := range dependenciesResponse.Dependencies {
+		components = append(components, sbom.Component{
+			Name:    dep.Name,
+			Version: dep.Version,
+			PURL:    dep.PURL,
+			License: dep.License,
+		})
+	}
+
+	return components
 }
 
 // Function to get vulnerabilities from FOSSA API
@@ -658,5 +1397,9 @@ func getVulnerabilities(project string) ([]models.VulnerabilityIssue, string, in
 		})
 	}
 
+	// Cross-reference each issue against OSV.dev/EPSS/KEV so --fail-on can
+	// gate on real-world exploitability, not just FOSSA's own severity.
+	issues = enrich.EnrichAll(issues)
+
 	return issues, fossaLink, issuesResponse.Dependencies
 }
@@ -0,0 +1,86 @@
+// Package ai provides an optional LLM-backed enrichment step for GitHub
+// issue content: a plain-English impact summary (with severity-triage
+// reasoning folded in) and a suggested remediation command, drafted from a
+// vulnerability's FOSSA data. Every Client is best-effort - a failure never
+// blocks issue creation, it just falls back to the raw FOSSA fields - so
+// callers should log and continue rather than propagate DraftIssue errors.
+package ai
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// httpClient is shared by every backend, mirroring internal/enrich's
+// bounded-timeout client so a slow or hung LLM endpoint can't stall a scan.
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Client drafts AI-assisted content for a vulnerability found in project.
+// summary is a plain-English impact summary and severity-triage
+// reasoning; remediation is a suggested fix command or snippet (e.g.
+// "yarn up lodash@4.17.21" scoped to project).
+type Client interface {
+	DraftIssue(ctx context.Context, project string, vuln models.VulnerabilityIssue) (summary, remediation string, err error)
+}
+
+// Config selects and configures the LLM backend. An empty Provider
+// disables AI enrichment entirely (New returns a NoopClient).
+type Config struct {
+	// Provider is one of "openai", "anthropic", "bedrock", "ollama", or ""
+	// to disable AI enrichment.
+	Provider string
+	// Model is the provider-specific model identifier, e.g. "gpt-4o-mini",
+	// "claude-3-5-sonnet-20241022",
+	// "anthropic.claude-3-sonnet-20240229-v1:0" (bedrock), or "llama3"
+	// (ollama).
+	Model string
+	// APIKey authenticates to openai/anthropic. Unused for "bedrock"
+	// (which uses AccessKeyID/SecretAccessKey) and for "ollama".
+	APIKey string
+	// Endpoint overrides the provider's default endpoint: the full request
+	// URL for "openai"/"anthropic", or the server base URL for "ollama"
+	// (e.g. a local "http://localhost:11434"). "bedrock" ignores it.
+	Endpoint string
+	// AccessKeyID, SecretAccessKey, and Region authenticate to AWS
+	// Bedrock via SigV4; Region also selects the bedrock-runtime
+	// endpoint.
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+}
+
+// New returns the Client selected by cfg.Provider, or a NoopClient if
+// Provider is empty or unrecognized.
+func New(cfg Config) Client {
+	switch cfg.Provider {
+	case "openai":
+		return &openAIClient{cfg: cfg}
+	case "anthropic":
+		return &anthropicClient{cfg: cfg}
+	case "bedrock":
+		return &bedrockClient{cfg: cfg}
+	case "ollama":
+		return &ollamaClient{cfg: cfg}
+	default:
+		return NoopClient{}
+	}
+}
+
+// NoopClient is the default Client: it drafts nothing and never errors,
+// so AI enrichment is opt-in.
+type NoopClient struct{}
+
+// DraftIssue implements Client by declining to draft anything.
+func (NoopClient) DraftIssue(ctx context.Context, project string, vuln models.VulnerabilityIssue) (string, string, error) {
+	return "", "", nil
+}
+
+func modelOr(model, fallback string) string {
+	if model != "" {
+		return model
+	}
+	return fallback
+}
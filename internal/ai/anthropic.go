@@ -0,0 +1,75 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+const (
+	anthropicDefaultEndpoint = "https://api.anthropic.com/v1/messages"
+	anthropicVersion         = "2023-06-01"
+)
+
+// anthropicClient drafts issue content via the Anthropic Messages API.
+type anthropicClient struct{ cfg Config }
+
+func (c *anthropicClient) DraftIssue(ctx context.Context, project string, vuln models.VulnerabilityIssue) (string, string, error) {
+	endpoint := c.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = anthropicDefaultEndpoint
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model":      modelOr(c.cfg.Model, "claude-3-5-sonnet-20241022"),
+		"max_tokens": 512,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt(project, vuln)},
+		},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", c.cfg.APIKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("anthropic: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("anthropic: reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("anthropic: returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var parsed struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return "", "", fmt.Errorf("anthropic: decoding response: %w", err)
+	}
+	if len(parsed.Content) == 0 {
+		return "", "", fmt.Errorf("anthropic: empty response")
+	}
+
+	summary, remediation := parseDraft(parsed.Content[0].Text)
+	return summary, remediation, nil
+}
@@ -0,0 +1,149 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// bedrockDefaultModel assumes an Anthropic model served through Bedrock,
+// the most common choice for this kind of free-text drafting task; its
+// request/response shape (anthropic_version, messages, content blocks)
+// differs from the other model families Bedrock hosts.
+const bedrockDefaultModel = "anthropic.claude-3-sonnet-20240229-v1:0"
+
+// bedrockClient drafts issue content via AWS Bedrock's InvokeModel API,
+// authenticated with a hand-rolled SigV4 signer (pulling in the AWS SDK
+// for one request type isn't worth the dependency weight).
+type bedrockClient struct{ cfg Config }
+
+func (c *bedrockClient) DraftIssue(ctx context.Context, project string, vuln models.VulnerabilityIssue) (string, string, error) {
+	model := modelOr(c.cfg.Model, bedrockDefaultModel)
+	host := fmt.Sprintf("bedrock-runtime.%s.amazonaws.com", c.cfg.Region)
+	endpoint := fmt.Sprintf("https://%s/model/%s/invoke", host, model)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"anthropic_version": "bedrock-2023-05-31",
+		"max_tokens":        512,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt(project, vuln)},
+		},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	if err := signSigV4(req, body, c.cfg.AccessKeyID, c.cfg.SecretAccessKey, c.cfg.Region, "bedrock", time.Now().UTC()); err != nil {
+		return "", "", fmt.Errorf("bedrock: signing request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("bedrock: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("bedrock: reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("bedrock: returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var parsed struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return "", "", fmt.Errorf("bedrock: decoding response: %w", err)
+	}
+	if len(parsed.Content) == 0 {
+		return "", "", fmt.Errorf("bedrock: empty response")
+	}
+
+	summary, remediation := parseDraft(parsed.Content[0].Text)
+	return summary, remediation, nil
+}
+
+// signSigV4 signs req in place with AWS Signature Version 4, covering just
+// what a single POST with a JSON body and no query string needs (host,
+// content-type, and x-amz-date as signed headers).
+func signSigV4(req *http.Request, body []byte, accessKeyID, secretAccessKey, region, service string, now time.Time) error {
+	if accessKeyID == "" || secretAccessKey == "" {
+		return fmt.Errorf("missing AWS credentials")
+	}
+
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-date:%s\n",
+		req.Header.Get("Content-Type"), req.URL.Host, amzDate)
+
+	payloadHash := sha256Hex(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"", // no query string
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	credentialScope := fmt.Sprintf("%s/%s/%s/aws4_request", dateStamp, region, service)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := sigV4Key(secretAccessKey, dateStamp, region, service)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, credentialScope, signedHeaders, signature))
+
+	return nil
+}
+
+func sigV4Key(secretAccessKey, dateStamp, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretAccessKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+const ollamaDefaultEndpoint = "http://localhost:11434"
+
+// ollamaClient drafts issue content via a local (or self-hosted) Ollama
+// server's generate API, so AI enrichment can run without sending
+// vulnerability data to a third-party provider.
+type ollamaClient struct{ cfg Config }
+
+func (c *ollamaClient) DraftIssue(ctx context.Context, project string, vuln models.VulnerabilityIssue) (string, string, error) {
+	endpoint := c.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = ollamaDefaultEndpoint
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/") + "/api/generate"
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model":  modelOr(c.cfg.Model, "llama3"),
+		"prompt": prompt(project, vuln),
+		"stream": false,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("ollama: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("ollama: reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("ollama: returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var parsed struct {
+		Response string `json:"response"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return "", "", fmt.Errorf("ollama: decoding response: %w", err)
+	}
+
+	summary, remediation := parseDraft(parsed.Response)
+	return summary, remediation, nil
+}
@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+const openAIDefaultEndpoint = "https://api.openai.com/v1/chat/completions"
+
+// openAIClient drafts issue content via the OpenAI Chat Completions API.
+type openAIClient struct{ cfg Config }
+
+func (c *openAIClient) DraftIssue(ctx context.Context, project string, vuln models.VulnerabilityIssue) (string, string, error) {
+	endpoint := c.cfg.Endpoint
+	if endpoint == "" {
+		endpoint = openAIDefaultEndpoint
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"model": modelOr(c.cfg.Model, "gpt-4o-mini"),
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt(project, vuln)},
+		},
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("openai: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("openai: reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("openai: returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	var parsed struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return "", "", fmt.Errorf("openai: decoding response: %w", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return "", "", fmt.Errorf("openai: empty response")
+	}
+
+	summary, remediation := parseDraft(parsed.Choices[0].Message.Content)
+	return summary, remediation, nil
+}
@@ -0,0 +1,69 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// prompt builds the shared instruction every backend sends: enough FOSSA
+// context to draft a useful triage, plus a strict two-section response
+// format so parseDraft can split the reply back into summary/remediation
+// regardless of which LLM answered.
+func prompt(project string, vuln models.VulnerabilityIssue) string {
+	cve := vuln.CVE
+	if cve == "" {
+		cve = "unknown"
+	}
+
+	fixedIn := vuln.FixedIn
+	if fixedIn == "" {
+		fixedIn = "unknown"
+	}
+
+	return fmt.Sprintf(`You are a security engineer triaging a dependency vulnerability for the Nx project %q.
+
+Vulnerability: %s
+CVE: %s
+Severity (as reported by FOSSA): %s
+Description: %s
+Fixed in: %s
+
+Respond with exactly two sections, each on its own line(s):
+SUMMARY: a 2-3 sentence plain-English impact summary, including your severity-triage reasoning.
+REMEDIATION: the exact command to fix it, e.g. "yarn up %s@%s" scoped to this project if a fixed version is known, or an alternative mitigation otherwise.`,
+		project, vuln.Name, cve, vuln.Severity, vuln.Description, fixedIn, vuln.Name, fixedIn)
+}
+
+// parseDraft splits an LLM's reply into its SUMMARY: and REMEDIATION:
+// sections. Missing sections come back empty rather than erroring, since a
+// slightly-off-format reply is still better used partially than discarded.
+func parseDraft(text string) (summary, remediation string) {
+	lines := strings.Split(text, "\n")
+
+	var section *strings.Builder
+	var summaryBuilder, remediationBuilder strings.Builder
+
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(line), "SUMMARY:"):
+			section = &summaryBuilder
+			line = strings.TrimPrefix(strings.TrimSpace(line), "SUMMARY:")
+		case strings.HasPrefix(strings.TrimSpace(line), "REMEDIATION:"):
+			section = &remediationBuilder
+			line = strings.TrimPrefix(strings.TrimSpace(line), "REMEDIATION:")
+		}
+
+		if section == nil {
+			continue
+		}
+
+		if section.Len() > 0 {
+			section.WriteString("\n")
+		}
+		section.WriteString(strings.TrimSpace(line))
+	}
+
+	return strings.TrimSpace(summaryBuilder.String()), strings.TrimSpace(remediationBuilder.String())
+}
@@ -0,0 +1,267 @@
+// Package cache persists FOSSA scan results on disk, keyed by a hash of a
+// project's lockfiles, so unchanged projects can skip re-scanning entirely.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// DefaultMaxEntries bounds how many cache entries are kept on disk before
+// the oldest (by last access) are evicted.
+const DefaultMaxEntries = 500
+
+// lockfileNames is checked, in order, under a project's root (and the
+// workspace root, for hoisted installs) when computing a cache key.
+var lockfileNames = []string{
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"go.sum",
+	"requirements.txt",
+	"poetry.lock",
+	"pom.xml",
+}
+
+// Entry is one project's cached scan result.
+type Entry struct {
+	Key             string                      `json:"key"`
+	Project         string                      `json:"project"`
+	Revision        string                      `json:"revision"`
+	CachedAt        time.Time                   `json:"cachedAt"`
+	Issues          []models.VulnerabilityIssue `json:"issues"`
+	FossaLink       string                      `json:"fossaLink"`
+	DependencyCount int                         `json:"dependencyCount"`
+}
+
+// Cache is a disk-backed, LRU-evicted store of Entry, one JSON file per key
+// under dir, plus an index file tracking access order for eviction.
+type Cache struct {
+	dir        string
+	ttl        time.Duration
+	maxEntries int
+
+	mu     sync.Mutex
+	hits   int64
+	misses int64
+}
+
+// New returns a Cache rooted at dir. Entries older than ttl are treated as
+// misses; once more than maxEntries are stored, the least-recently-used are
+// evicted.
+func New(dir string, ttl time.Duration, maxEntries int) *Cache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &Cache{dir: dir, ttl: ttl, maxEntries: maxEntries}
+}
+
+// ComputeKey derives a stable cache key for projectName from the contents of
+// its resolved lockfiles (package-lock.json, yarn.lock, go.sum, etc.),
+// including a shared workspace-root lockfile when one exists. It errors if
+// no lockfile can be found, since caching without one isn't safe.
+func ComputeKey(projectName, projectRoot string) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(projectName))
+
+	found := false
+	for _, name := range lockfileNames {
+		if hashFileInto(h, filepath.Join(projectRoot, name), name) {
+			found = true
+		}
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		for _, name := range lockfileNames {
+			if hashFileInto(h, filepath.Join(cwd, name), "root:"+name) {
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		return "", fmt.Errorf("no lockfile found for %s under %s, refusing to cache", projectName, projectRoot)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFileInto(h hash.Hash, path, label string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	fmt.Fprintf(h, "%s:", label)
+	h.Write(data)
+	return true
+}
+
+// Get returns the cached entry for key if present and within ttl.
+func (c *Cache) Get(key string) (*Entry, bool) {
+	data, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+
+	if c.ttl > 0 && time.Since(entry.CachedAt) > c.ttl {
+		atomic.AddInt64(&c.misses, 1)
+		return nil, false
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+	c.touch(key)
+	return &entry, true
+}
+
+// Put stores entry, stamping CachedAt, and evicts the least-recently-used
+// entries if this push puts the cache over its max size.
+func (c *Cache) Put(entry Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", c.dir, err)
+	}
+
+	entry.CachedAt = time.Now()
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize cache entry: %w", err)
+	}
+
+	if err := os.WriteFile(c.entryPath(entry.Key), data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	c.touchLocked(entry.Key)
+	return c.evictLocked()
+}
+
+// Prune removes every entry that has already expired and reports how many
+// were removed.
+func (c *Cache) Prune() (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache directory %s: %w", c.dir, err)
+	}
+
+	removed := 0
+	for _, info := range entries {
+		if info.IsDir() || info.Name() == indexFileName {
+			continue
+		}
+
+		path := filepath.Join(c.dir, info.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+
+		if c.ttl > 0 && time.Since(entry.CachedAt) > c.ttl {
+			os.Remove(path)
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// Stats returns the number of hits and misses observed by this Cache so far.
+func (c *Cache) Stats() (hits, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
+
+func (c *Cache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+const indexFileName = "index.json"
+
+func (c *Cache) indexPath() string {
+	return filepath.Join(c.dir, indexFileName)
+}
+
+func (c *Cache) touch(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.touchLocked(key)
+}
+
+// touchLocked moves key to the most-recently-used end of the index, which
+// callers must load/save under c.mu.
+func (c *Cache) touchLocked(key string) {
+	order := c.loadIndexLocked()
+
+	filtered := order[:0]
+	for _, k := range order {
+		if k != key {
+			filtered = append(filtered, k)
+		}
+	}
+	filtered = append(filtered, key)
+
+	c.saveIndexLocked(filtered)
+}
+
+func (c *Cache) evictLocked() error {
+	order := c.loadIndexLocked()
+	if len(order) <= c.maxEntries {
+		return nil
+	}
+
+	evictCount := len(order) - c.maxEntries
+	for _, key := range order[:evictCount] {
+		os.Remove(c.entryPath(key))
+	}
+
+	c.saveIndexLocked(order[evictCount:])
+	return nil
+}
+
+func (c *Cache) loadIndexLocked() []string {
+	data, err := os.ReadFile(c.indexPath())
+	if err != nil {
+		return nil
+	}
+
+	var order []string
+	if err := json.Unmarshal(data, &order); err != nil {
+		return nil
+	}
+
+	return order
+}
+
+func (c *Cache) saveIndexLocked(order []string) {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return
+	}
+	os.MkdirAll(c.dir, 0755)
+	os.WriteFile(c.indexPath(), data, 0644)
+}
@@ -0,0 +1,66 @@
+package enrich
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CacheDir and CacheTTL bound how often the NVD and GHSA lookups below hit
+// the network, since both are rate-limited. They're package-level
+// variables, like sbom.ToolVersion, rather than an option threaded through
+// every call; main() leaves them at these defaults.
+var (
+	CacheDir = ".fossa-nx/enrich-cache"
+	CacheTTL = 24 * time.Hour
+)
+
+// cacheEntry is the NVD/GHSA enrichment result for one CVE, persisted as
+// its own JSON file under CacheDir (mirroring internal/cache's layout) so a
+// batch re-run doesn't re-query either API for CVEs it already has fresh
+// data for.
+type cacheEntry struct {
+	CVE             string    `json:"cve"`
+	CachedAt        time.Time `json:"cachedAt"`
+	CVSSScore       float64   `json:"cvssScore,omitempty"`
+	CVSSVector      string    `json:"cvssVector,omitempty"`
+	PatchedVersions []string  `json:"patchedVersions,omitempty"`
+}
+
+func loadCacheEntry(cve string) (cacheEntry, bool) {
+	data, err := os.ReadFile(cacheEntryPath(cve))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+
+	if CacheTTL > 0 && time.Since(entry.CachedAt) > CacheTTL {
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+func saveCacheEntry(entry cacheEntry) {
+	entry.CachedAt = time.Now()
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(CacheDir, 0755); err != nil {
+		return
+	}
+
+	os.WriteFile(cacheEntryPath(entry.CVE), data, 0644)
+}
+
+func cacheEntryPath(cve string) string {
+	return filepath.Join(CacheDir, cve+".json")
+}
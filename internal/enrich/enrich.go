@@ -0,0 +1,237 @@
+// Package enrich cross-references FOSSA-reported vulnerabilities against
+// external vulnerability intelligence (OSV.dev, the NVD 2.0 API, GitHub
+// Security Advisories, the FIRST EPSS API, and CISA's Known Exploited
+// Vulnerabilities catalog) so policy gating can tell a truly exploitable
+// issue apart from a theoretical one. NVD and GHSA lookups are cached on
+// disk (see cache.go) since both are rate-limited.
+package enrich
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+const (
+	osvVulnURL = "https://api.osv.dev/v1/vulns/"
+	epssURL    = "https://api.first.org/data/v1/epss"
+	kevURL     = "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// EnrichAll enriches every issue in place (best-effort: a lookup failure for
+// one issue leaves it unchanged rather than aborting the batch) and returns
+// the same slice for convenience.
+func EnrichAll(issues []models.VulnerabilityIssue) []models.VulnerabilityIssue {
+	for i := range issues {
+		Enrich(&issues[i])
+	}
+	return issues
+}
+
+// Enrich fills in issue's EPSS score, CVSS vector and base score, aliases,
+// KEV flag, patched version ranges, and (if FOSSA didn't already report
+// one) a fixed-version range, by querying OSV.dev, the NVD 2.0 API, GitHub
+// Security Advisories (if GitHubToken is set), and the FIRST EPSS API for
+// issue.CVE. It's a no-op if CVE is empty.
+func Enrich(issue *models.VulnerabilityIssue) {
+	if issue.CVE == "" {
+		return
+	}
+
+	if vuln, ok := queryOSV(issue.CVE); ok {
+		issue.Aliases = vuln.Aliases
+		if vector := cvssVector(vuln.Severity); vector != "" {
+			issue.CVSSVector = vector
+		}
+		if issue.FixedIn == "" {
+			issue.FixedIn = firstFixedVersion(vuln.Affected)
+		}
+	}
+
+	if score, ok := queryEPSS(issue.CVE); ok {
+		issue.EPSSScore = score
+	}
+
+	issue.KEV = isKnownExploited(issue.CVE)
+
+	enrichFromNVDAndGHSA(issue)
+}
+
+// enrichFromNVDAndGHSA fills in CVSSScore and PatchedVersions, consulting
+// the on-disk cache before querying NVD/GHSA, and populating it afterward.
+func enrichFromNVDAndGHSA(issue *models.VulnerabilityIssue) {
+	if cached, ok := loadCacheEntry(issue.CVE); ok {
+		applyCacheEntry(issue, cached)
+		return
+	}
+
+	entry := cacheEntry{CVE: issue.CVE}
+
+	if score, vector, ok := queryNVD(issue.CVE); ok {
+		entry.CVSSScore = score
+		if vector != "" {
+			entry.CVSSVector = vector
+		}
+	}
+
+	if patched, ok := queryGHSA(issue.CVE); ok {
+		entry.PatchedVersions = patched
+	}
+
+	applyCacheEntry(issue, entry)
+	saveCacheEntry(entry)
+}
+
+func applyCacheEntry(issue *models.VulnerabilityIssue, entry cacheEntry) {
+	issue.CVSSScore = entry.CVSSScore
+	if issue.CVSSVector == "" {
+		issue.CVSSVector = entry.CVSSVector
+	}
+	issue.PatchedVersions = entry.PatchedVersions
+}
+
+type osvSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type osvEvent struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"`
+}
+
+type osvRange struct {
+	Events []osvEvent `json:"events"`
+}
+
+type osvAffected struct {
+	Ranges []osvRange `json:"ranges"`
+}
+
+type osvVuln struct {
+	ID       string        `json:"id"`
+	Aliases  []string      `json:"aliases"`
+	Severity []osvSeverity `json:"severity"`
+	Affected []osvAffected `json:"affected"`
+}
+
+func queryOSV(cve string) (osvVuln, bool) {
+	resp, err := httpClient.Get(osvVulnURL + cve)
+	if err != nil {
+		return osvVuln{}, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return osvVuln{}, false
+	}
+
+	var vuln osvVuln
+	if err := json.NewDecoder(resp.Body).Decode(&vuln); err != nil {
+		return osvVuln{}, false
+	}
+
+	return vuln, true
+}
+
+func cvssVector(severities []osvSeverity) string {
+	for _, s := range severities {
+		if strings.HasPrefix(s.Type, "CVSS") {
+			return s.Score
+		}
+	}
+	return ""
+}
+
+func firstFixedVersion(affected []osvAffected) string {
+	for _, a := range affected {
+		for _, r := range a.Ranges {
+			for _, e := range r.Events {
+				if e.Fixed != "" {
+					return e.Fixed
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func queryEPSS(cve string) (float64, bool) {
+	resp, err := httpClient.Get(fmt.Sprintf("%s?cve=%s", epssURL, cve))
+	if err != nil {
+		return 0, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, false
+	}
+
+	var epssResponse struct {
+		Data []struct {
+			EPSS string `json:"epss"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&epssResponse); err != nil || len(epssResponse.Data) == 0 {
+		return 0, false
+	}
+
+	var score float64
+	if _, err := fmt.Sscanf(epssResponse.Data[0].EPSS, "%g", &score); err != nil {
+		return 0, false
+	}
+
+	return score, true
+}
+
+var (
+	kevOnce sync.Once
+	kevSet  map[string]bool
+)
+
+// isKnownExploited reports whether cve is in CISA's Known Exploited
+// Vulnerabilities catalog, fetched and cached once per process.
+func isKnownExploited(cve string) bool {
+	kevOnce.Do(func() {
+		kevSet = loadKEVCatalog()
+	})
+	return kevSet[cve]
+}
+
+func loadKEVCatalog() map[string]bool {
+	set := make(map[string]bool)
+
+	resp, err := httpClient.Get(kevURL)
+	if err != nil {
+		return set
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return set
+	}
+
+	var catalog struct {
+		Vulnerabilities []struct {
+			CveID string `json:"cveID"`
+		} `json:"vulnerabilities"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&catalog); err != nil {
+		return set
+	}
+
+	for _, v := range catalog.Vulnerabilities {
+		set[v.CveID] = true
+	}
+
+	return set
+}
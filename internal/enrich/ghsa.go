@@ -0,0 +1,100 @@
+package enrich
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const ghsaGraphQLURL = "https://api.github.com/graphql"
+
+// GitHubToken authorizes the GitHub Security Advisory GraphQL lookup below;
+// GHSA enrichment is skipped entirely when it's empty, since the GraphQL
+// API (unlike NVD and EPSS) requires authentication.
+var GitHubToken string
+
+const ghsaQuery = `
+query($cve: String!) {
+  securityAdvisories(identifier: {type: CVE, value: $cve}, first: 1) {
+    nodes {
+      vulnerabilities(first: 20) {
+        nodes {
+          vulnerableVersionRange
+          firstPatchedVersion { identifier }
+        }
+      }
+    }
+  }
+}`
+
+type ghsaResponse struct {
+	Data struct {
+		SecurityAdvisories struct {
+			Nodes []struct {
+				Vulnerabilities struct {
+					Nodes []struct {
+						VulnerableVersionRange string `json:"vulnerableVersionRange"`
+						FirstPatchedVersion    *struct {
+							Identifier string `json:"identifier"`
+						} `json:"firstPatchedVersion"`
+					} `json:"nodes"`
+				} `json:"vulnerabilities"`
+			} `json:"nodes"`
+		} `json:"securityAdvisories"`
+	} `json:"data"`
+}
+
+// queryGHSA fetches the first patched version GitHub records for each
+// affected package in cve's security advisory; packages with no patched
+// release are skipped. Returns ok=false if GitHubToken is unset, the
+// advisory isn't found, no package has a patched version, or the request
+// fails.
+func queryGHSA(cve string) (patchedVersions []string, ok bool) {
+	if GitHubToken == "" {
+		return nil, false
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     ghsaQuery,
+		"variables": map[string]string{"cve": cve},
+	})
+	if err != nil {
+		return nil, false
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ghsaGraphQLURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, false
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("bearer %s", GitHubToken))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false
+	}
+
+	var parsed ghsaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, false
+	}
+
+	advisories := parsed.Data.SecurityAdvisories.Nodes
+	if len(advisories) == 0 {
+		return nil, false
+	}
+
+	for _, vuln := range advisories[0].Vulnerabilities.Nodes {
+		if vuln.FirstPatchedVersion != nil && vuln.FirstPatchedVersion.Identifier != "" {
+			patchedVersions = append(patchedVersions, vuln.FirstPatchedVersion.Identifier)
+		}
+	}
+
+	return patchedVersions, len(patchedVersions) > 0
+}
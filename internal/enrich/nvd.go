@@ -0,0 +1,70 @@
+package enrich
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const nvdURL = "https://services.nvd.nist.gov/rest/json/cves/2.0"
+
+// NVDApiKey raises the NVD 2.0 API's default rate limit when set; obtain
+// one at https://nvd.nist.gov/developers/request-an-api-key. Unset, lookups
+// still work but are throttled more aggressively.
+var NVDApiKey string
+
+type nvdResponse struct {
+	Vulnerabilities []struct {
+		Cve struct {
+			Metrics struct {
+				CvssMetricV31 []struct {
+					CvssData struct {
+						VectorString string  `json:"vectorString"`
+						BaseScore    float64 `json:"baseScore"`
+					} `json:"cvssData"`
+				} `json:"cvssMetricV31"`
+			} `json:"metrics"`
+		} `json:"cve"`
+	} `json:"vulnerabilities"`
+}
+
+// queryNVD fetches the CVSS v3.1 base score and vector for cve from the NVD
+// 2.0 JSON API.
+func queryNVD(cve string) (score float64, vector string, ok bool) {
+	req, err := http.NewRequest(http.MethodGet, nvdURL, nil)
+	if err != nil {
+		return 0, "", false
+	}
+	q := req.URL.Query()
+	q.Set("cveId", cve)
+	req.URL.RawQuery = q.Encode()
+
+	if NVDApiKey != "" {
+		req.Header.Set("apiKey", NVDApiKey)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, "", false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, "", false
+	}
+
+	var parsed nvdResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return 0, "", false
+	}
+
+	if len(parsed.Vulnerabilities) == 0 {
+		return 0, "", false
+	}
+
+	metrics := parsed.Vulnerabilities[0].Cve.Metrics.CvssMetricV31
+	if len(metrics) == 0 {
+		return 0, "", false
+	}
+
+	return metrics[0].CvssData.BaseScore, metrics[0].CvssData.VectorString, true
+}
@@ -0,0 +1,183 @@
+package export
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/nx"
+)
+
+// osvEntry is one vulnerability in the OSV 1.x schema
+// (https://ossf.github.io/osv-schema/), the format govulncheck,
+// Dependency-Track, and Grype all consume natively.
+type osvEntry struct {
+	ID               string                 `json:"id"`
+	Aliases          []string               `json:"aliases,omitempty"`
+	Summary          string                 `json:"summary,omitempty"`
+	Details          string                 `json:"details,omitempty"`
+	Modified         string                 `json:"modified"`
+	Published        string                 `json:"published,omitempty"`
+	Affected         []osvAffected          `json:"affected,omitempty"`
+	References       []osvReference         `json:"references,omitempty"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific,omitempty"`
+}
+
+type osvAffected struct {
+	Package osvPackage `json:"package"`
+	Ranges  []osvRange `json:"ranges,omitempty"`
+}
+
+type osvPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+type osvRange struct {
+	Type   string          `json:"type"`
+	Events []osvRangeEvent `json:"events"`
+}
+
+type osvRangeEvent struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"`
+}
+
+type osvReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// osvEcosystems maps fossa-nx's internal ecosystem identifiers (from
+// nx.ManifestHandler.Ecosystem, e.g. "node", "go") to the ecosystem names
+// OSV consumers expect.
+var osvEcosystems = map[string]string{
+	"node":   "npm",
+	"pnpm":   "npm",
+	"go":     "Go",
+	"python": "PyPI",
+	"maven":  "Maven",
+}
+
+// WriteOSV renders results as OSV 1.x entries, writing one JSON file per
+// finding (named after its OSV id) plus a combined osv.json array, to
+// outputDir. It returns the combined file's path.
+func WriteOSV(outputDir string, results []models.Result) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create OSV output directory %s: %w", outputDir, err)
+	}
+
+	var combined []osvEntry
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+
+		ecosystem := osvEcosystemFor(result.Project)
+
+		for _, issue := range result.Issues {
+			entry := toOSVEntry(result, issue, ecosystem)
+			combined = append(combined, entry)
+
+			data, err := json.MarshalIndent(entry, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("failed to serialize OSV entry %s: %w", entry.ID, err)
+			}
+
+			path := filepath.Join(outputDir, entry.ID+".json")
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return "", fmt.Errorf("failed to write OSV entry %s: %w", path, err)
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(combined, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize combined OSV report: %w", err)
+	}
+
+	combinedPath := filepath.Join(outputDir, "osv.json")
+	if err := os.WriteFile(combinedPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write combined OSV report %s: %w", combinedPath, err)
+	}
+
+	return combinedPath, nil
+}
+
+func toOSVEntry(result models.Result, issue models.VulnerabilityIssue, ecosystem string) osvEntry {
+	id := issue.CVE
+	if id == "" {
+		id = synthesizeOSVID(result.Project, issue)
+	}
+
+	timestamp := issue.FirstSeen
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+	modified := timestamp.UTC().Format(time.RFC3339)
+
+	var references []osvReference
+	if result.FossaLink != "" {
+		references = append(references, osvReference{Type: "WEB", URL: result.FossaLink})
+	}
+	if issue.Link != "" {
+		references = append(references, osvReference{Type: "WEB", URL: issue.Link})
+	}
+
+	return osvEntry{
+		ID:        id,
+		Aliases:   issue.Aliases,
+		Summary:   issue.Name,
+		Details:   issue.Description,
+		Modified:  modified,
+		Published: modified,
+		Affected: []osvAffected{{
+			Package: osvPackage{Ecosystem: ecosystem, Name: issue.Name},
+			Ranges:  []osvRange{{Type: "SEMVER", Events: osvEvents(issue)}},
+		}},
+		References: references,
+		DatabaseSpecific: map[string]interface{}{
+			"severity":    issue.Severity,
+			"cvss_score":  issue.CVSSScore,
+			"cvss_vector": issue.CVSSVector,
+			"epss_score":  issue.EPSSScore,
+			"kev":         issue.KEV,
+		},
+	}
+}
+
+func osvEvents(issue models.VulnerabilityIssue) []osvRangeEvent {
+	events := []osvRangeEvent{{Introduced: "0"}}
+	if issue.FixedIn != "" {
+		events = append(events, osvRangeEvent{Fixed: issue.FixedIn})
+	}
+	return events
+}
+
+// synthesizeOSVID builds a "FOSSA-<hash>" id for a finding with no CVE, from
+// a sha1 of the project and vulnerability name so the same finding gets the
+// same id across runs.
+func synthesizeOSVID(project string, issue models.VulnerabilityIssue) string {
+	sum := sha1.Sum([]byte(project + "|" + issue.Name))
+	return "FOSSA-" + hex.EncodeToString(sum[:8])
+}
+
+// osvEcosystemFor resolves the OSV ecosystem name for project by reusing
+// nx's own ecosystem detection (explicit mapping.TeamMapping.Ecosystem
+// override, falling back to sniffing the project root's contents), so OSV
+// output always agrees with whichever manifest fossa-nx actually scanned.
+func osvEcosystemFor(project string) string {
+	root, err := nx.GetProjectRoot(project)
+	if err != nil {
+		return ""
+	}
+
+	handler := nx.SelectManifestHandler(project, root)
+	return osvEcosystems[handler.Ecosystem()]
+}
@@ -0,0 +1,190 @@
+// Package export renders scan results into CI-native vulnerability
+// formats: SARIF 2.1.0 (for GitHub Advanced Security code-scanning upload)
+// and CycloneDX 1.5 VEX JSON (for supply-chain tooling), alongside the
+// human-facing email report and FOSSA's own dashboard.
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/nx"
+)
+
+const (
+	toolName       = "fossa-nx"
+	sarifSchemaURL = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+)
+
+// ToolVersion is stamped into every generated document's tool metadata. It's
+// set from main's version variable at startup, like sbom.ToolVersion.
+var ToolVersion = "dev"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string              `json:"id"`
+	Name             string              `json:"name"`
+	ShortDescription sarifMessage        `json:"shortDescription"`
+	FullDescription  sarifMessage        `json:"fullDescription"`
+	HelpURI          string              `json:"helpUri,omitempty"`
+	Properties       sarifRuleProperties `json:"properties,omitempty"`
+}
+
+type sarifRuleProperties struct {
+	SecuritySeverity string `json:"security-severity,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// levelForSeverity maps FOSSA's severity strings to SARIF result levels:
+// GitHub code scanning treats "error" as blocking and "note" as informational.
+func levelForSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "high", "critical":
+		return "error"
+	case "medium", "moderate":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// WriteSARIF renders results as a SARIF 2.1.0 log and writes it to path.
+// Each issue becomes one SARIF result, with ruleId set to its CVE (falling
+// back to its name if FOSSA didn't report one) and a location pointing at
+// the project's root in the Nx workspace.
+func WriteSARIF(path string, results []models.Result) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			Version:        ToolVersion,
+			InformationURI: "https://github.com/kamalesh-seervi/fossa-nx",
+		}},
+	}
+
+	seenRules := make(map[string]bool)
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+
+		uri := projectURI(result.Project)
+
+		for _, issue := range result.Issues {
+			ruleID := issue.CVE
+			if ruleID == "" {
+				ruleID = issue.Name
+			}
+
+			if !seenRules[ruleID] {
+				seenRules[ruleID] = true
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:               ruleID,
+					Name:             issue.Name,
+					ShortDescription: sarifMessage{Text: issue.Name},
+					FullDescription:  sarifMessage{Text: issue.Description},
+					HelpURI:          issue.Link,
+					Properties:       sarifRuleProperties{SecuritySeverity: fmt.Sprintf("%.1f", issue.CVSSScore)},
+				})
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  ruleID,
+				Level:   levelForSeverity(issue.Severity),
+				Message: sarifMessage{Text: fmt.Sprintf("%s: %s (%s)", result.Project, issue.Name, issue.Severity)},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: uri},
+					},
+				}},
+				PartialFingerprints: map[string]string{
+					"fossaNx/v1": fingerprint(ruleID, result.Project),
+				},
+			})
+		}
+	}
+
+	doc := sarifLog{
+		Schema:  sarifSchemaURL,
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize SARIF report: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write SARIF report %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// projectURI resolves project's Nx workspace root for a SARIF artifact
+// location, falling back to the bare project name if the root can't be
+// determined (e.g. running against a shard manifest with no live workspace).
+func projectURI(project string) string {
+	if root, err := nx.GetProjectRoot(project); err == nil && root != "" {
+		return root
+	}
+	return project
+}
+
+// fingerprint derives a stable identifier GitHub code scanning can use to
+// dedupe the same issue across repeated uploads, from the CVE/name and the
+// affected project.
+func fingerprint(ruleID, project string) string {
+	sum := sha256.Sum256([]byte(ruleID + "|" + project))
+	return hex.EncodeToString(sum[:16])
+}
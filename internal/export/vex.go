@@ -0,0 +1,150 @@
+package export
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// cyclonedxVEX is a CycloneDX 1.5 Vulnerability Exploitability eXchange
+// document: a BOM with no components, carrying only a vulnerabilities list
+// so CI can gate on "is anything actually exploitable" without re-shipping
+// the full dependency graph alongside it.
+type cyclonedxVEX struct {
+	BomFormat       string             `json:"bomFormat"`
+	SpecVersion     string             `json:"specVersion"`
+	SerialNumber    string             `json:"serialNumber"`
+	Version         int                `json:"version"`
+	Metadata        vexMetadata        `json:"metadata"`
+	Vulnerabilities []vexVulnerability `json:"vulnerabilities"`
+}
+
+type vexMetadata struct {
+	Timestamp string    `json:"timestamp"`
+	Tools     []vexTool `json:"tools"`
+}
+
+type vexTool struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type vexVulnerability struct {
+	ID          string       `json:"id"`
+	Source      vexSource    `json:"source,omitempty"`
+	Ratings     []vexRating  `json:"ratings,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Affects     []vexAffects `json:"affects"`
+	Analysis    vexAnalysis  `json:"analysis"`
+}
+
+type vexSource struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type vexRating struct {
+	Source   vexSource `json:"source,omitempty"`
+	Score    float64   `json:"score,omitempty"`
+	Severity string    `json:"severity"`
+	Vector   string    `json:"vector,omitempty"`
+}
+
+type vexAffects struct {
+	Ref string `json:"ref"`
+}
+
+// vexAnalysis records VEX's required exploitability verdict for each
+// vulnerability. fossa-nx doesn't track manual triage decisions, so every
+// entry is reported "in_triage" unless CISA's KEV catalog confirms active
+// exploitation, in which case it's "exploitable" - a conservative default
+// that never silently downgrades a real finding.
+type vexAnalysis struct {
+	State string `json:"state"`
+}
+
+var vexNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// WriteVEX renders results as a CycloneDX 1.5 VEX document and writes it to
+// path. Each issue becomes one vulnerability entry, affects-referencing its
+// project by sanitized name, with analysis.state derived from the KEV flag.
+func WriteVEX(path string, results []models.Result) error {
+	doc := cyclonedxVEX{
+		BomFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + newVEXUUID(),
+		Version:      1,
+		Metadata: vexMetadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools:     []vexTool{{Name: toolName, Version: ToolVersion}},
+		},
+	}
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+
+		ref := vexNameSanitizer.ReplaceAllString(result.Project, "-")
+
+		for _, issue := range result.Issues {
+			id := issue.CVE
+			if id == "" {
+				id = issue.Name
+			}
+
+			state := "in_triage"
+			if issue.KEV {
+				state = "exploitable"
+			}
+
+			var ratings []vexRating
+			if issue.CVSSScore > 0 || issue.CVSSVector != "" {
+				ratings = append(ratings, vexRating{
+					Score:    issue.CVSSScore,
+					Severity: strings.ToLower(issue.Severity),
+					Vector:   issue.CVSSVector,
+				})
+			}
+
+			doc.Vulnerabilities = append(doc.Vulnerabilities, vexVulnerability{
+				ID:          id,
+				Source:      vexSource{Name: "FOSSA", URL: issue.Link},
+				Ratings:     ratings,
+				Description: issue.Description,
+				Affects:     []vexAffects{{Ref: ref}},
+				Analysis:    vexAnalysis{State: state},
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize VEX report: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write VEX report %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// newVEXUUID generates an RFC 4122 v4 UUID, mirroring sbom.newUUID: falling
+// back to an all-zero UUID in the near-impossible case that crypto/rand
+// fails, rather than panicking over a non-critical document ID.
+func newVEXUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "00000000-0000-0000-0000-000000000000"
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
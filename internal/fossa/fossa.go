@@ -8,30 +8,16 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/kamalesh-seervi/fossa-nx/internal/log"
 	"github.com/kamalesh-seervi/fossa-nx/internal/mapping"
 	"github.com/kamalesh-seervi/fossa-nx/internal/nx"
 )
 
-// Global mutex for filesystem operations to prevent race conditions
 var (
-	fsLock           sync.Mutex
-	monorepoRootOnce sync.Once
-	monorepoRootDir  string
-	monorepoRootErr  error
-	filteredEnvOnce  sync.Once
-	filteredEnvVars  []string
+	filteredEnvOnce sync.Once
+	filteredEnvVars []string
 )
 
-// getMonorepoRoot returns the root directory of the monorepo (cached)
-func getMonorepoRoot() (string, error) {
-	monorepoRootOnce.Do(func() {
-		var err error
-		monorepoRootDir, err = os.Getwd()
-		monorepoRootErr = err
-	})
-	return monorepoRootDir, monorepoRootErr
-}
-
 // getFilteredEnv returns environment variables without SSL_CERT_DIR (cached)
 func getFilteredEnv() []string {
 	filteredEnvOnce.Do(func() {
@@ -47,7 +33,10 @@ func getFilteredEnv() []string {
 	return filteredEnvVars
 }
 
-// RunAnalysis runs FOSSA analysis for a project with optimized performance
+// RunAnalysis runs FOSSA analysis for a project directly in its real,
+// in-tree project root. For concurrent scans, prefer Scheduler.Run, which
+// isolates each project in an ephemeral workspace instead of mutating the
+// repo in place.
 func RunAnalysis(projectName string) error {
 	// Verify project is mapped in config
 	if !mapping.IsProjectMapped(projectName) {
@@ -66,53 +55,23 @@ func RunAnalysis(projectName string) error {
 		return fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	// Get monorepo root (cached)
-	monorepoRoot, err := getMonorepoRoot()
-	if err != nil {
-		return fmt.Errorf("failed to get monorepo root: %w", err)
-	}
-
-	// State tracking variables
-	var (
-		packageJsonPath         = filepath.Join(absProjectRoot, "package.json")
-		originalPackageJson     []byte
-		packageJsonExists       = false
-		nodeModulesPath         = filepath.Join(absProjectRoot, "node_modules")
-		monorepoNodeModulesPath = filepath.Join(monorepoRoot, "node_modules")
-		nodeModulesCreated      = false
-	)
-
-	// Backup package.json if it exists
-	fsLock.Lock()
-	if _, err := os.Stat(packageJsonPath); err == nil {
-		packageJsonExists = true
-		originalPackageJson, err = os.ReadFile(packageJsonPath)
-		if err != nil {
-			fsLock.Unlock()
-			return fmt.Errorf("failed to read package.json: %w", err)
-		}
-	}
-	fsLock.Unlock()
-
-	// Create or update package.json with dependencies (with lock)
-	fsLock.Lock()
-	_, err = nx.CreateTemporaryPackageJson(projectName, absProjectRoot)
-	fsLock.Unlock()
+	return runInRoot(projectName, absProjectRoot)
+}
 
+// runInRoot performs the actual FOSSA analyze/test cycle rooted at
+// absProjectRoot, which may be a project's real in-tree directory
+// (RunAnalysis) or an ephemeral, isolated copy of it (Scheduler). Since each
+// caller supplies its own, independent root, no locking is needed between
+// concurrent calls.
+func runInRoot(projectName, absProjectRoot string) error {
+	// Select and run the ecosystem-specific manifest handler (Node, pnpm,
+	// Go, Python, Maven), which mutates files under absProjectRoot.
+	handler := nx.SelectManifestHandler(projectName, absProjectRoot)
+
+	restoreManifest, err := handler.Prepare(projectName, absProjectRoot)
 	if err != nil {
-		return fmt.Errorf("failed to create temporary package.json: %w", err)
-	}
-
-	// Check if node_modules symlink is needed
-	fsLock.Lock()
-	if _, err := os.Stat(nodeModulesPath); os.IsNotExist(err) {
-		if err := os.Symlink(monorepoNodeModulesPath, nodeModulesPath); err != nil {
-			fsLock.Unlock()
-			return fmt.Errorf("failed to create node_modules symlink: %w", err)
-		}
-		nodeModulesCreated = true
+		return fmt.Errorf("failed to prepare %s manifest: %w", handler.Ecosystem(), err)
 	}
-	fsLock.Unlock()
 
 	// Get team value and project ID for FOSSA from config (cached)
 	teamValue := mapping.GetTeamValue(projectName)
@@ -134,21 +93,22 @@ func RunAnalysis(projectName string) error {
 		gitBranchName = strings.TrimSpace(string(gitBranchOutput))
 	}
 
+	projectLog := log.ForProject(log.ProjectContext{
+		Project:        projectName,
+		FossaProjectID: fossaProject,
+		Team:           teamValue,
+		GitCommit:      gitCommitHash,
+		GitBranch:      gitBranchName,
+	})
+
 	// Ensure cleanup of temporary resources when done
 	defer func() {
-		fsLock.Lock()
-		// Restore original package.json
-		if packageJsonExists {
-			os.WriteFile(packageJsonPath, originalPackageJson, 0644)
-		} else {
-			os.Remove(packageJsonPath)
-		}
-
-		// Remove node_modules symlink if we created it
-		if nodeModulesCreated {
-			os.Remove(nodeModulesPath)
+		restoreErr := restoreManifest()
+		if restoreErr != nil {
+			projectLog.Phase(log.PhaseCleanup).WithError(restoreErr).Error("failed to restore manifest after scan")
+			return
 		}
-		fsLock.Unlock()
+		projectLog.Phase(log.PhaseCleanup).Info("restored manifest to its pre-scan state")
 	}()
 
 	// Get filtered environment variables (cached)
@@ -173,14 +133,17 @@ func RunAnalysis(projectName string) error {
 
 	analyzeArgs = append(analyzeArgs, "--policy", "Website/Hosted Service Use")
 
+	analyzeOutput := projectLog.StreamWriter(log.PhaseAnalyze)
 	analyzeCmd := exec.Command("fossa", analyzeArgs...)
 	analyzeCmd.Dir = absProjectRoot
 	analyzeCmd.Env = filteredEnv
-	analyzeCmd.Stdout = os.Stdout
-	analyzeCmd.Stderr = os.Stderr
+	analyzeCmd.Stdout = analyzeOutput
+	analyzeCmd.Stderr = analyzeOutput
 
-	if err := analyzeCmd.Run(); err != nil {
-		return fmt.Errorf("FOSSA analyze command failed: %w", err)
+	analyzeErr := analyzeCmd.Run()
+	analyzeOutput.Flush()
+	if analyzeErr != nil {
+		return fmt.Errorf("FOSSA analyze command failed: %w", analyzeErr)
 	}
 
 	// Run FOSSA test command with minimal arguments
@@ -194,14 +157,17 @@ func RunAnalysis(projectName string) error {
 		testArgs = append(testArgs, "-r", gitCommitHash)
 	}
 
+	testOutput := projectLog.StreamWriter(log.PhaseTest)
 	testCmd := exec.Command("fossa", testArgs...)
 	testCmd.Dir = absProjectRoot
 	testCmd.Env = filteredEnv
-	testCmd.Stdout = os.Stdout
-	testCmd.Stderr = os.Stderr
+	testCmd.Stdout = testOutput
+	testCmd.Stderr = testOutput
 
-	if err := testCmd.Run(); err != nil {
-		return fmt.Errorf("FOSSA test command failed: %w", err)
+	testErr := testCmd.Run()
+	testOutput.Flush()
+	if testErr != nil {
+		return fmt.Errorf("FOSSA test command failed: %w", testErr)
 	}
 
 	return nil
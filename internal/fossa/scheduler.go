@@ -0,0 +1,143 @@
+package fossa
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/nx"
+)
+
+// Scheduler runs FOSSA scans for many projects concurrently, each inside its
+// own ephemeral copy of the project's source tree. That per-project
+// isolation is what lets scans run in parallel without the global fsLock
+// RunAnalysis used to need to serialize manifest edits.
+type Scheduler struct {
+	concurrency int
+}
+
+// NewScheduler returns a Scheduler bounded to at most concurrency
+// simultaneous scans. concurrency <= 0 is treated as 1.
+func NewScheduler(concurrency int) *Scheduler {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return &Scheduler{concurrency: concurrency}
+}
+
+// Run scans every project concurrently, bounded by s.concurrency, and
+// streams a models.Result on the returned channel as soon as each project
+// finishes. stats.InFlight is kept up to date for the duration of the run if
+// stats is non-nil.
+func (s *Scheduler) Run(ctx context.Context, projects []string, stats *models.Stats) <-chan models.Result {
+	resultCh := make(chan models.Result, len(projects))
+	sem := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+
+	for _, project := range projects {
+		wg.Add(1)
+		go func(project string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			if stats != nil {
+				atomic.AddInt32(&stats.InFlight, 1)
+				defer atomic.AddInt32(&stats.InFlight, -1)
+			}
+
+			start := time.Now()
+			err := s.scanIsolated(project)
+
+			resultCh <- models.Result{
+				Project:  project,
+				Error:    err,
+				Duration: time.Since(start),
+				EndTime:  time.Now(),
+			}
+		}(project)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	return resultCh
+}
+
+// scanIsolated copies project's source tree into an ephemeral workspace
+// under os.TempDir(), runs the scan rooted there instead of in-tree, and
+// removes the workspace once the scan completes.
+func (s *Scheduler) scanIsolated(projectName string) error {
+	projectRoot, err := nx.GetProjectRoot(projectName)
+	if err != nil {
+		return fmt.Errorf("failed to get project root: %w", err)
+	}
+
+	absProjectRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	workspace, err := os.MkdirTemp("", "fossa-nx-workspace-")
+	if err != nil {
+		return fmt.Errorf("failed to create ephemeral workspace: %w", err)
+	}
+	defer os.RemoveAll(workspace)
+
+	if err := hardlinkTree(absProjectRoot, workspace); err != nil {
+		return fmt.Errorf("failed to isolate workspace for %s: %w", projectName, err)
+	}
+
+	return runInRoot(projectName, workspace)
+}
+
+// hardlinkTree recreates src's directory structure under dst, hardlinking
+// regular files (falling back to a copy if src and dst sit on different
+// filesystems) and skipping symlinks - handler.Prepare recreates whatever
+// symlinks (e.g. node_modules) the scan needs.
+func hardlinkTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return nil
+		}
+
+		if err := os.Link(path, target); err != nil {
+			data, readErr := os.ReadFile(path)
+			if readErr != nil {
+				return readErr
+			}
+			return os.WriteFile(target, data, info.Mode())
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,162 @@
+// Package log provides the structured, contextual logger shared by the
+// fossa, nx, and models subsystems. Records emitted through a ProjectLogger
+// carry a stable set of fields (project, fossa_project_id, team,
+// git_commit, git_branch) plus phase, so CI systems can filter and
+// aggregate output regardless of whether it's printed as text or JSON.
+// duration_ms is only present on records built with ProjectLogger.Duration,
+// and records from With carry only the fields passed to it.
+package log
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Format selects how log records are rendered.
+type Format string
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+)
+
+// Phase identifies which stage of a scan a log record belongs to.
+type Phase string
+
+const (
+	PhaseAnalyze Phase = "analyze"
+	PhaseTest    Phase = "test"
+	PhaseCleanup Phase = "cleanup"
+)
+
+var base = logrus.New()
+
+// Configure sets the output format and minimum level for all subsequent log
+// records. It should be called once, early in main, from the --log-format
+// and --log-level flags.
+func Configure(format Format, level string) {
+	if format == FormatJSON {
+		base.SetFormatter(&logrus.JSONFormatter{})
+	} else {
+		base.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	}
+
+	parsedLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		parsedLevel = logrus.InfoLevel
+	}
+	base.SetLevel(parsedLevel)
+	base.SetOutput(os.Stdout)
+}
+
+// Fields is a convenience alias for attaching ad-hoc context to a record.
+type Fields = logrus.Fields
+
+// Entry is the shared type returned by With* helpers so callers can chain
+// logrus' usual Info/Warn/Error/Debug methods.
+type Entry = logrus.Entry
+
+// With returns a base entry carrying the given fields, for call sites (like
+// nx.GetProjects) that log outside the scope of a single project scan.
+func With(fields Fields) *Entry {
+	return base.WithFields(fields)
+}
+
+// ProjectLogger carries the stable per-project fields for one scan: project,
+// fossa_project_id, team, git_commit, and git_branch. Every record emitted
+// through it - including re-emitted subprocess output - inherits those
+// fields plus a phase.
+type ProjectLogger struct {
+	entry *logrus.Entry
+}
+
+// ProjectContext holds the stable fields attached to every record for a scan.
+type ProjectContext struct {
+	Project        string
+	FossaProjectID string
+	Team           string
+	GitCommit      string
+	GitBranch      string
+}
+
+// ForProject returns a ProjectLogger scoped to a single project's scan.
+func ForProject(ctx ProjectContext) *ProjectLogger {
+	return &ProjectLogger{entry: base.WithFields(logrus.Fields{
+		"project":          ctx.Project,
+		"fossa_project_id": ctx.FossaProjectID,
+		"team":             ctx.Team,
+		"git_commit":       ctx.GitCommit,
+		"git_branch":       ctx.GitBranch,
+	})}
+}
+
+// Phase returns an entry tagged with the given phase, ready for Info/Error/etc.
+func (l *ProjectLogger) Phase(phase Phase) *logrus.Entry {
+	return l.entry.WithField("phase", string(phase))
+}
+
+// Duration returns an entry tagged with the given phase and elapsed duration
+// in milliseconds, for the final record of a phase.
+func (l *ProjectLogger) Duration(phase Phase, d time.Duration) *logrus.Entry {
+	return l.Phase(phase).WithField("duration_ms", d.Milliseconds())
+}
+
+// StreamWriter returns an io.Writer that scans whatever is written to it
+// line-by-line and re-emits each complete line as a structured record at
+// INFO, or ERROR if the line looks like a failure. It is meant to replace
+// piping a FOSSA subprocess's Stdout/Stderr directly to os.Stdout/os.Stderr.
+func (l *ProjectLogger) StreamWriter(phase Phase) *lineWriter {
+	entry := l.Phase(phase)
+	return &lineWriter{emit: func(line string) {
+		if looksLikeError(line) {
+			entry.Error(line)
+		} else {
+			entry.Info(line)
+		}
+	}}
+}
+
+func looksLikeError(line string) bool {
+	lower := strings.ToLower(line)
+	return strings.Contains(lower, "error") || strings.Contains(lower, "fail") || strings.Contains(lower, "fatal")
+}
+
+// lineWriter buffers partial writes and emits one record per complete line,
+// since exec.Cmd.Stdout/Stderr are written to in arbitrary-sized chunks.
+type lineWriter struct {
+	emit func(line string)
+	buf  []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+
+		line := string(bytes.TrimRight(w.buf[:idx], "\r"))
+		w.buf = w.buf[idx+1:]
+
+		if line != "" {
+			w.emit(line)
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush emits any trailing partial line left in the buffer. Callers should
+// defer it after wiring a StreamWriter to a command's Stdout/Stderr.
+func (w *lineWriter) Flush() {
+	if line := strings.TrimRight(string(w.buf), "\r\n"); line != "" {
+		w.emit(line)
+	}
+	w.buf = nil
+}
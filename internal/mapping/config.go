@@ -1,47 +1,75 @@
+// Package mapping resolves FOSSA project/team configuration from
+// fossa-config.yaml: which FOSSA project ID a given NX project maps to,
+// which team owns it, which notification channels and schedules are
+// configured. The config is held in a long-lived ConfigStore (store.go)
+// that watches the file via fsnotify and validates it against an embedded
+// JSON Schema (schema.go) before swapping it in, so a process that runs for
+// days - like `fossa-nx daemon` - picks up edits without a restart.
 package mapping
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 
 	"github.com/kamalesh-seervi/fossa-nx/internal/models"
-	"gopkg.in/yaml.v3"
 )
 
 var (
-	// globalConfig holds the loaded configuration
-	globalConfig *models.Config
-	configOnce   sync.Once
-	configError  error
+	defaultStore     *ConfigStore
+	defaultStoreOnce sync.Once
+	defaultStoreErr  error
 )
 
-// LoadConfig loads the configuration from the config file - using sync.Once for thread-safety
-func LoadConfig() (*models.Config, error) {
-	configOnce.Do(func() {
-		configError = loadConfigImpl()
+// DefaultStore opens (on first call) and returns the package's singleton
+// ConfigStore, for subsystems that want to Subscribe to edits or inspect
+// Status rather than just reading the latest config via LoadConfig.
+func DefaultStore() (*ConfigStore, error) {
+	defaultStoreOnce.Do(func() {
+		defaultStore, defaultStoreErr = openDefaultConfigStore()
 	})
-	return globalConfig, configError
+	return defaultStore, defaultStoreErr
+}
+
+// LoadConfig returns the current configuration from the default
+// ConfigStore, opening it on first call. Subsequent calls are a cheap
+// atomic read; the store keeps itself current in the background.
+func LoadConfig() (*models.Config, error) {
+	store, err := DefaultStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.Config(), nil
+}
+
+func openDefaultConfigStore() (*ConfigStore, error) {
+	path, err := resolveConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := NewConfigStore(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Loaded configuration from %s\n", path)
+	return store, nil
 }
 
-// loadConfigImpl is the actual implementation of config loading
-func loadConfigImpl() error {
-	// Get config path from environment if set
-	configPath := os.Getenv("FOSSA_CONFIG_PATH")
-
-	// If config path is set and file exists, use it
-	if configPath != "" {
-		if _, err := os.Stat(configPath); err == nil {
-			return loadConfigFromFile(configPath)
-		} else {
-			return fmt.Errorf("specified config file not found: %s", configPath)
+// resolveConfigPath finds the config file to load: $FOSSA_CONFIG_PATH if
+// set, else the first of the well-known filenames found in the current
+// directory, else in the home directory.
+func resolveConfigPath() (string, error) {
+	if configPath := os.Getenv("FOSSA_CONFIG_PATH"); configPath != "" {
+		if _, err := os.Stat(configPath); err != nil {
+			return "", fmt.Errorf("specified config file not found: %s", configPath)
 		}
+		return configPath, nil
 	}
 
-	// Check for config files in common locations (in order of preference)
 	configFiles := []string{
 		"fossa-config.yaml",
 		"fossa-config.yml",
@@ -49,57 +77,22 @@ func loadConfigImpl() error {
 		".fossa.yml",
 	}
 
-	// Try current directory first (most common case)
 	for _, filename := range configFiles {
 		if _, err := os.Stat(filename); err == nil {
-			return loadConfigFromFile(filename)
+			return filename, nil
 		}
 	}
 
-	// Try home directory next
-	home, err := os.UserHomeDir()
-	if err == nil {
+	if home, err := os.UserHomeDir(); err == nil {
 		for _, filename := range configFiles {
 			homeConfig := filepath.Join(home, filename)
 			if _, err := os.Stat(homeConfig); err == nil {
-				return loadConfigFromFile(homeConfig)
+				return homeConfig, nil
 			}
 		}
 	}
 
-	return fmt.Errorf("no configuration file found. Please create fossa-config.yaml in your project directory or home directory")
-}
-
-// loadConfigFromFile loads and parses a specific config file
-func loadConfigFromFile(configPath string) error {
-	// Read and parse the config file
-	configData, err := ioutil.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("error reading config file %s: %w", configPath, err)
-	}
-
-	config := &models.Config{}
-	if err = yaml.Unmarshal(configData, config); err != nil {
-		return fmt.Errorf("error parsing config file %s: %w", configPath, err)
-	}
-
-	// Validate required fields
-	if config.Fossa.Projects == nil || len(config.Fossa.Projects) == 0 {
-		return fmt.Errorf("missing or empty projects section in config file %s", configPath)
-	}
-
-	if config.Fossa.Teams == nil || len(config.Fossa.Teams) == 0 {
-		return fmt.Errorf("missing or empty teams section in config file %s", configPath)
-	}
-
-	if config.Fossa.Endpoint == "" {
-		return fmt.Errorf("missing endpoint in config file %s", configPath)
-	}
-
-	globalConfig = config
-	fmt.Printf("Loaded configuration from %s\n", configPath)
-
-	return nil
+	return "", fmt.Errorf("no configuration file found. Please create fossa-config.yaml in your project directory or home directory")
 }
 
 // IsProjectMapped returns whether a project is mapped in the configuration
@@ -148,6 +141,50 @@ func GetTeamValue(projectName string) string {
 	return config.Fossa.DefaultTeam
 }
 
+// GetEcosystem returns the configured ecosystem override for a project's
+// matching team mapping, or "" if none is configured and the ecosystem
+// should be auto-detected from the project root instead.
+func GetEcosystem(projectName string) string {
+	config, err := LoadConfig()
+	if err != nil {
+		return ""
+	}
+
+	for _, team := range config.Fossa.Teams {
+		for _, prefix := range team.Prefixes {
+			if strings.HasPrefix(projectName, prefix) {
+				return team.Ecosystem
+			}
+		}
+	}
+
+	return ""
+}
+
+// GetNotifiersConfig returns the optional `notifiers:` section from the
+// config file, or a zero-value NotifiersConfig if no config file is present
+// or it failed to load. Unlike the required Fossa fields, every notifier
+// channel already falls back to its own flag/env var, so a missing config
+// file here is not an error.
+func GetNotifiersConfig() models.NotifiersConfig {
+	config, err := LoadConfig()
+	if err != nil {
+		return models.NotifiersConfig{}
+	}
+	return config.Notifiers
+}
+
+// GetSchedules returns the optional `schedules:` section from the config
+// file, used by `fossa-nx daemon` to run recurring scans. Returns nil if no
+// config file is present or it failed to load.
+func GetSchedules() []models.ScheduleEntry {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil
+	}
+	return config.Schedules
+}
+
 // GetFossaEndpoint returns the configured FOSSA endpoint
 func GetFossaEndpoint() string {
 	config, err := LoadConfig()
@@ -0,0 +1,104 @@
+package mapping
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed schema.json
+var configSchemaJSON []byte
+
+// schemaNode is a hand-rolled JSON Schema node covering just the draft-07
+// keywords fossa-config.yaml's schema actually uses (type, properties,
+// required, items, minItems, minProperties). ConfigStore only ever
+// validates against the one embedded schema below, so pulling in a
+// general-purpose draft-07 engine for oneOf/anyOf/$ref/etc. isn't
+// warranted.
+type schemaNode struct {
+	Type          string                 `json:"type,omitempty"`
+	Required      []string               `json:"required,omitempty"`
+	Properties    map[string]*schemaNode `json:"properties,omitempty"`
+	Items         *schemaNode            `json:"items,omitempty"`
+	MinItems      int                    `json:"minItems,omitempty"`
+	MinProperties int                    `json:"minProperties,omitempty"`
+}
+
+var compiledConfigSchema = mustCompileSchema(configSchemaJSON)
+
+func mustCompileSchema(data []byte) *schemaNode {
+	var node schemaNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		panic(fmt.Sprintf("mapping: embedded config schema is invalid JSON: %v", err))
+	}
+	return &node
+}
+
+// validateConfig parses data as generic YAML and checks the result against
+// the embedded schema. This runs independently of yaml.Unmarshal into
+// *models.Config, which would otherwise silently zero-value any missing
+// required field instead of rejecting the file.
+func validateConfig(data []byte) error {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	return compiledConfigSchema.validate("", doc)
+}
+
+func (n *schemaNode) validate(path string, value interface{}) error {
+	if n == nil {
+		return nil
+	}
+
+	switch n.Type {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an object", fieldPath(path))
+		}
+		if n.MinProperties > 0 && len(obj) < n.MinProperties {
+			return fmt.Errorf("%s: must have at least %d entries", fieldPath(path), n.MinProperties)
+		}
+		for _, req := range n.Required {
+			if _, ok := obj[req]; !ok {
+				return fmt.Errorf("%s: missing required field %q", fieldPath(path), req)
+			}
+		}
+		for key, child := range n.Properties {
+			if v, ok := obj[key]; ok {
+				if err := child.validate(path+"."+key, v); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an array", fieldPath(path))
+		}
+		if len(arr) < n.MinItems {
+			return fmt.Errorf("%s: must have at least %d entries", fieldPath(path), n.MinItems)
+		}
+		for i, item := range arr {
+			if err := n.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected a string", fieldPath(path))
+		}
+	}
+
+	return nil
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "config"
+	}
+	return "config" + path
+}
@@ -0,0 +1,175 @@
+package mapping
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Event is sent to every subscriber each time ConfigStore swaps in a newly
+// validated configuration.
+type Event struct {
+	Config *models.Config
+}
+
+// ConfigStore holds the live-reloaded configuration for one config file. It
+// watches the file via fsnotify and atomically swaps in a freshly validated
+// config on every edit, so a long-lived process like `fossa-nx daemon` picks
+// up changes without a restart. A validation failure leaves the previous
+// good config in place; Status reports the failure instead.
+type ConfigStore struct {
+	path    string
+	current atomic.Pointer[models.Config]
+
+	mu          sync.Mutex
+	statusErr   error
+	subscribers []chan Event
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewConfigStore loads and validates path, then starts watching it for
+// changes. The initial load must succeed.
+func NewConfigStore(path string) (*ConfigStore, error) {
+	store := &ConfigStore{path: path, done: make(chan struct{})}
+
+	if err := store.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start config file watcher: %w", err)
+	}
+
+	// Watch the containing directory rather than the file itself: editors
+	// and config-management tools commonly replace a file via
+	// rename-into-place, which most filesystems report as a Remove+Create
+	// on the directory rather than a Write on the original file handle.
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch config directory: %w", err)
+	}
+
+	store.watcher = watcher
+	go store.watch()
+
+	return store, nil
+}
+
+func (s *ConfigStore) watch() {
+	for {
+		select {
+		case event, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(s.path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := s.reload(); err != nil {
+				log.Printf("mapping: failed to reload %s: %v (keeping previous configuration)", s.path, err)
+			}
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("mapping: config file watcher error: %v", err)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// reload re-reads and validates the config file, swapping it in only on
+// success.
+func (s *ConfigStore) reload() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		loadErr := fmt.Errorf("error reading config file %s: %w", s.path, err)
+		s.setStatus(loadErr)
+		return loadErr
+	}
+
+	if err := validateConfig(data); err != nil {
+		loadErr := fmt.Errorf("config file %s failed schema validation: %w", s.path, err)
+		s.setStatus(loadErr)
+		return loadErr
+	}
+
+	config := &models.Config{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		loadErr := fmt.Errorf("error parsing config file %s: %w", s.path, err)
+		s.setStatus(loadErr)
+		return loadErr
+	}
+
+	s.current.Store(config)
+	s.setStatus(nil)
+	s.notify(Event{Config: config})
+
+	return nil
+}
+
+func (s *ConfigStore) setStatus(err error) {
+	s.mu.Lock()
+	s.statusErr = err
+	s.mu.Unlock()
+}
+
+// Status returns the error from the most recent reload attempt, or nil if
+// it succeeded. A non-nil Status doesn't mean Config is unusable - it means
+// Config is still serving the last known-good version.
+func (s *ConfigStore) Status() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statusErr
+}
+
+// Config returns the current validated configuration. It is never nil once
+// NewConfigStore has returned successfully.
+func (s *ConfigStore) Config() *models.Config {
+	return s.current.Load()
+}
+
+// Subscribe registers ch to receive an Event every time the config file is
+// successfully reloaded. Sends are non-blocking: a subscriber that isn't
+// keeping up misses intermediate reloads rather than stalling the watcher.
+func (s *ConfigStore) Subscribe(ch chan Event) {
+	s.mu.Lock()
+	s.subscribers = append(s.subscribers, ch)
+	s.mu.Unlock()
+}
+
+func (s *ConfigStore) notify(event Event) {
+	s.mu.Lock()
+	subs := append([]chan Event(nil), s.subscribers...)
+	s.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Close stops the file watcher. Safe to call at most once.
+func (s *ConfigStore) Close() error {
+	close(s.done)
+	if s.watcher != nil {
+		return s.watcher.Close()
+	}
+	return nil
+}
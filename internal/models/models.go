@@ -2,6 +2,8 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
 	"time"
 )
 
@@ -14,6 +16,17 @@ type VulnerabilityIssue struct {
 	CVE         string    `json:"cve,omitempty"`
 	FirstSeen   time.Time `json:"firstSeen,omitempty"`
 	FixedIn     string    `json:"fixedIn,omitempty"`
+
+	// The following are filled in by internal/enrich, cross-referencing
+	// OSV.dev, the NVD 2.0 API, GitHub Security Advisories, the FIRST EPSS
+	// API, and CISA's KEV catalog; they're left zero-valued until
+	// enrichment runs.
+	EPSSScore       float64  `json:"epssScore,omitempty"`       // likelihood of exploitation in the next 30 days, 0-1
+	CVSSVector      string   `json:"cvssVector,omitempty"`      // e.g. "CVSS:3.1/AV:N/AC:L/..."
+	CVSSScore       float64  `json:"cvssScore,omitempty"`       // CVSS v3.1 base score, 0-10, from the NVD 2.0 API
+	Aliases         []string `json:"aliases,omitempty"`         // other identifiers for the same vulnerability (CVE, GHSA, ...)
+	KEV             bool     `json:"kev,omitempty"`             // listed in CISA's Known Exploited Vulnerabilities catalog
+	PatchedVersions []string `json:"patchedVersions,omitempty"` // ecosystem-specific patched version ranges, from GHSA
 }
 
 // Result represents the result of a FOSSA scan for a project
@@ -27,6 +40,63 @@ type Result struct {
 	DependencyCount int
 }
 
+// resultJSON mirrors Result field-for-field, substituting Error's bare
+// `error` interface with a plain string: encoding/json marshals an `error`
+// to `{}` (it has no exported fields) and then can never unmarshal that
+// back into an interface, so a Result round-tripped through JSON as-is
+// loses a failed scan's error on the way out and fails outright on the way
+// back in. Result.MarshalJSON/UnmarshalJSON go through this shape instead.
+type resultJSON struct {
+	Project         string
+	Error           string `json:",omitempty"`
+	Duration        time.Duration
+	EndTime         time.Time
+	Issues          []VulnerabilityIssue
+	FossaLink       string
+	DependencyCount int
+}
+
+// MarshalJSON stringifies Error via resultJSON so a Result for a failed
+// scan round-trips through JSON instead of silently marshaling to `{}`.
+func (r Result) MarshalJSON() ([]byte, error) {
+	rj := resultJSON{
+		Project:         r.Project,
+		Duration:        r.Duration,
+		EndTime:         r.EndTime,
+		Issues:          r.Issues,
+		FossaLink:       r.FossaLink,
+		DependencyCount: r.DependencyCount,
+	}
+	if r.Error != nil {
+		rj.Error = r.Error.Error()
+	}
+	return json.Marshal(rj)
+}
+
+// UnmarshalJSON restores Error from the string MarshalJSON wrote, via
+// errors.New - every consumer only ever checks Error != nil or formats it
+// with %v/%s, so losing the original concrete error type is fine.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var rj resultJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return err
+	}
+
+	r.Project = rj.Project
+	r.Duration = rj.Duration
+	r.EndTime = rj.EndTime
+	r.Issues = rj.Issues
+	r.FossaLink = rj.FossaLink
+	r.DependencyCount = rj.DependencyCount
+
+	r.Error = nil
+	if rj.Error != "" {
+		r.Error = errors.New(rj.Error)
+	}
+
+	return nil
+}
+
 // EmailConfig holds email notification configuration
 type EmailConfig struct {
 	SmtpServer   string
@@ -45,6 +115,39 @@ type GitHubConfig struct {
 	Repository   string
 	ApiUrl       string // GitHub API URL for Enterprise instances
 	Enabled      bool
+
+	// CreateIssues gates whether github.CreateIssues files/updates issues at
+	// all; Enabled alone also drives CreateCommitStatus, and remediation PRs
+	// additionally need AutoFix.
+	CreateIssues bool
+	// DedupeKey is set from --github-dedupe-key ("project+cve" by default),
+	// but github.CreateIssues does not read it: an open FOSSA issue is always
+	// matched on project plus CVE (or the vulnerability name when no CVE is
+	// known), and the vulnerable package and version are not part of the
+	// match.
+	DedupeKey string
+	// AutoCloseResolved closes open FOSSA issues whose fingerprint no
+	// longer appears in the current scan, posting a "resolved" comment
+	// first.
+	AutoCloseResolved bool
+	// FailOn is the policy.Evaluate condition string (e.g. "high", "kev")
+	// that determines when a project's Check Run concludes "failure"
+	// rather than "neutral". Empty means findings alone never fail a Check
+	// Run; a project whose scan errored still concludes "failure".
+	FailOn string
+	// AutoFix gates whether runRemediation opens fix PRs at all; it's
+	// opt-in since auto-bumping dependencies and pushing branches is a
+	// more invasive action than filing an issue.
+	AutoFix bool
+	// AutoFixSeverity is a policy.Evaluate condition string gating which
+	// vulnerabilities are eligible for an automatic fix PR once AutoFix is
+	// set. Empty means every vulnerability with a FixedIn version is
+	// eligible.
+	AutoFixSeverity string
+	// DefaultBranch is the base branch remediation PRs target. Empty
+	// resolves it from the repo's origin/HEAD at publish time, falling back
+	// to "main" if that can't be determined.
+	DefaultBranch string
 }
 
 // FossaConfig holds all FOSSA-related configuration
@@ -61,14 +164,77 @@ type TeamMapping struct {
 	Prefixes      []string `yaml:"prefixes"`
 	TeamValue     string   `yaml:"teamValue"`
 	CheckmarxPath string   `yaml:"checkmarxPath"`
+	// Ecosystem overrides auto-detection of which nx.ManifestHandler to use
+	// for projects matching this mapping's prefixes (e.g. "node", "pnpm",
+	// "go", "python", "maven"). Left empty, the handler is detected from the
+	// project root's contents.
+	Ecosystem string `yaml:"ecosystem"`
 }
 
 // Config holds the entire application configuration
 type Config struct {
-	Fossa FossaConfig `yaml:"fossa"`
+	Fossa     FossaConfig     `yaml:"fossa"`
+	Notifiers NotifiersConfig `yaml:"notifiers"`
+	Schedules []ScheduleEntry `yaml:"schedules"`
+}
+
+// ScheduleEntry configures one recurring scan run by `fossa-nx daemon`: Cron
+// is a standard 5-field cron expression (minute hour day-of-month month
+// day-of-week), with day-of-week also accepting the MON-SUN names, e.g.
+// "0 9 * * MON". Window bounds how far back the digest's "new since last
+// report"/"resolved since last report" comparison looks, expressed as a
+// duration string with an additional "d" (day) unit, e.g. "7d" or "12h".
+type ScheduleEntry struct {
+	Name   string `yaml:"name"`
+	Cron   string `yaml:"schedule"`
+	Window string `yaml:"window"`
+}
+
+// NotifiersConfig holds per-channel config for the generalized notify
+// subsystem (internal/notify/slack, internal/notify/teams,
+// internal/notify/webhook, internal/notify/pagerduty), as an alternative to
+// passing the equivalent flags or env vars. Slack, Teams and the webhook
+// each take a MinSeverity, so a team can, e.g., send every result by email
+// but only post to Slack on critical findings. PagerDutyConfig has no
+// MinSeverity: PagerDuty pages only when the run's high/critical count
+// exceeds its Threshold.
+type NotifiersConfig struct {
+	Slack     SlackConfig     `yaml:"slack"`
+	Teams     TeamsConfig     `yaml:"teams"`
+	Webhook   WebhookConfig   `yaml:"webhook"`
+	PagerDuty PagerDutyConfig `yaml:"pagerduty"`
+}
+
+// SlackConfig holds Slack incoming-webhook configuration.
+type SlackConfig struct {
+	WebhookURL  string `yaml:"webhookUrl"`
+	MinSeverity string `yaml:"minSeverity"`
+}
+
+// TeamsConfig holds Microsoft Teams incoming-webhook configuration.
+type TeamsConfig struct {
+	WebhookURL  string `yaml:"webhookUrl"`
+	MinSeverity string `yaml:"minSeverity"`
+}
+
+// WebhookConfig holds generic outbound webhook configuration.
+type WebhookConfig struct {
+	URL         string `yaml:"url"`
+	Secret      string `yaml:"secret"`
+	MinSeverity string `yaml:"minSeverity"`
+}
+
+// PagerDutyConfig holds PagerDuty Events v2 configuration. An incident is
+// only triggered once the run's high/critical severity issue count exceeds
+// Threshold, since most runs shouldn't page anyone.
+type PagerDutyConfig struct {
+	RoutingKey string `yaml:"routingKey"`
+	Threshold  int    `yaml:"threshold"`
 }
 
-// Stats tracks execution metrics for FOSSA scan operations
+// Stats tracks execution metrics for FOSSA scan operations. Every field is
+// updated via sync/atomic so it can be shared between the scheduler's scan
+// goroutines and whatever reads a live progress snapshot.
 type Stats struct {
 	TotalProjects   int32
 	Successful      int32
@@ -77,4 +243,5 @@ type Stats struct {
 	TotalDuration   int64 // nanoseconds
 	MaxDuration     int64 // nanoseconds
 	MinDuration     int64 // nanoseconds
+	InFlight        int32 // projects currently being scanned
 }
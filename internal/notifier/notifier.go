@@ -0,0 +1,328 @@
+// Package notifier publishes FOSSA scan results as GitHub Check Runs - one
+// per project, annotated at the manifest file FOSSA actually scanned, with
+// a Markdown findings table and a conclusion driven by
+// models.GitHubConfig.FailOn - and a sticky pull request comment, giving
+// reviewers the same inline feedback loop CI-oriented security bots
+// provide.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v71/github"
+	"github.com/kamalesh-seervi/fossa-nx/internal/git"
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/nx"
+	"github.com/kamalesh-seervi/fossa-nx/internal/policy"
+	"golang.org/x/oauth2"
+)
+
+// manifestFilenames maps fossa-nx's internal ecosystem identifiers (from
+// nx.ManifestHandler.Ecosystem) to the manifest/lockfile annotations should
+// point reviewers at.
+var manifestFilenames = map[string]string{
+	"node":   "package.json",
+	"pnpm":   "package.json",
+	"go":     "go.mod",
+	"python": "requirements.txt",
+	"maven":  "pom.xml",
+}
+
+// manifestPathFor resolves the manifest file a project's vulnerabilities
+// should be annotated against, via the same project-root/ecosystem
+// detection RunAnalysis uses, so the Check Run points reviewers at the
+// actual file FOSSA scanned rather than a hardcoded guess.
+func manifestPathFor(project string) string {
+	root, err := nx.GetProjectRoot(project)
+	if err != nil {
+		return "package.json"
+	}
+
+	handler := nx.SelectManifestHandler(project, root)
+	name, ok := manifestFilenames[handler.Ecosystem()]
+	if !ok {
+		name = "package.json"
+	}
+
+	if root == "" {
+		return name
+	}
+	return root + "/" + name
+}
+
+// stickyCommentMarker identifies the PR comment this notifier owns, so
+// re-runs refresh it in place instead of posting a new one each time.
+const stickyCommentMarker = "<!-- fossa-nx:scan-summary -->"
+
+// Notifier publishes per-project Check Runs and a sticky PR comment
+// summarizing a scan, diffed against the last scan stored for the current
+// branch; the pull request's base branch is not consulted.
+type Notifier struct {
+	config models.GitHubConfig
+	store  Store
+}
+
+// New returns a Notifier bound to config and backed by store for result
+// history. Publish is a no-op if config.Enabled is false.
+func New(config models.GitHubConfig, store Store) *Notifier {
+	return &Notifier{config: config, store: store}
+}
+
+// Publish creates a Check Run per project and refreshes the sticky PR
+// comment with a per-project summary table and a count of vulnerabilities
+// absent from that previous scan.
+func (n *Notifier) Publish(ctx context.Context, results []models.Result) error {
+	if !n.config.Enabled {
+		return nil
+	}
+
+	client := n.newClient(ctx)
+
+	commit, err := git.GetCommitHash()
+	if err != nil || commit == "" {
+		return fmt.Errorf("failed to resolve git commit for check runs: %w", err)
+	}
+
+	branch, _ := git.GetBranchName()
+
+	for _, result := range results {
+		if err := n.publishCheckRun(ctx, client, commit, result); err != nil {
+			log.Printf("failed to publish check run for %s: %v", result.Project, err)
+		}
+	}
+
+	previous, err := n.store.Latest(n.config.Repository, branch)
+	if err != nil {
+		log.Printf("failed to load previous scan for %s/%s: %v", n.config.Repository, branch, err)
+	}
+
+	if err := n.publishStickyComment(ctx, client, branch, results, previous); err != nil {
+		log.Printf("failed to publish PR comment: %v", err)
+	}
+
+	return n.store.Save(n.config.Repository, branch, commit, StoredScan{
+		SavedAt: time.Now(),
+		Results: results,
+	})
+}
+
+func (n *Notifier) newClient(ctx context.Context) *github.Client {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: n.config.Token})
+	tc := oauth2.NewClient(ctx, ts)
+	client := github.NewClient(tc)
+
+	if n.config.ApiUrl != "" {
+		if baseURL, err := url.Parse(n.config.ApiUrl + "/"); err == nil {
+			client.BaseURL = baseURL
+			client.UploadURL = baseURL
+		}
+	}
+
+	return client
+}
+
+func (n *Notifier) publishCheckRun(ctx context.Context, client *github.Client, commit string, result models.Result) error {
+	name := fmt.Sprintf("fossa-nx/%s", result.Project)
+
+	conclusion := "success"
+	switch {
+	case result.Error != nil:
+		conclusion = "failure"
+	case len(result.Issues) > 0:
+		conclusion = "neutral"
+	}
+
+	if n.config.FailOn != "" {
+		if failed, err := policy.Evaluate([]models.Result{result}, n.config.FailOn); err != nil {
+			log.Printf("invalid --fail-on policy %q, leaving check run conclusion as %q: %v", n.config.FailOn, conclusion, err)
+		} else if failed {
+			conclusion = "failure"
+		}
+	}
+
+	summary := fmt.Sprintf("%d dependencies scanned, %d vulnerabilities found", result.DependencyCount, len(result.Issues))
+	if result.Error != nil {
+		summary = fmt.Sprintf("scan failed: %v", result.Error)
+	}
+
+	output := &github.CheckRunOutput{
+		Title:       github.Ptr(name),
+		Summary:     github.Ptr(summary),
+		Text:        github.Ptr(renderCheckRunText(result)),
+		Annotations: annotationsFor(result),
+	}
+
+	_, _, err := client.Checks.CreateCheckRun(ctx, n.config.Organization, n.config.Repository, github.CreateCheckRunOptions{
+		Name:       name,
+		HeadSHA:    commit,
+		Status:     github.Ptr("completed"),
+		Conclusion: github.Ptr(conclusion),
+		Output:     output,
+	})
+
+	return err
+}
+
+// renderCheckRunText builds the Check Run's Markdown "text" body: a table
+// of every vulnerability found in result, for the reviewer who expands the
+// check rather than only reading the per-line annotations.
+func renderCheckRunText(result models.Result) string {
+	if len(result.Issues) == 0 {
+		return "No vulnerabilities found."
+	}
+
+	var b strings.Builder
+	b.WriteString("| Severity | Vulnerability | CVE | Fixed in |\n")
+	b.WriteString("|---|---|---|---|\n")
+
+	for _, issue := range result.Issues {
+		cve := issue.CVE
+		if cve == "" {
+			cve = "-"
+		}
+		fixedIn := issue.FixedIn
+		if fixedIn == "" {
+			fixedIn = "-"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", issue.Severity, issue.Name, cve, fixedIn))
+	}
+
+	return b.String()
+}
+
+func annotationsFor(result models.Result) []*github.CheckRunAnnotation {
+	annotations := make([]*github.CheckRunAnnotation, 0, len(result.Issues))
+	path := manifestPathFor(result.Project)
+	manifest, readErr := os.ReadFile(path)
+
+	for _, issue := range result.Issues {
+		line := 1
+		if readErr == nil {
+			if found, ok := dependencyLine(string(manifest), issue.Name); ok {
+				line = found
+			}
+		}
+
+		annotations = append(annotations, &github.CheckRunAnnotation{
+			Path:            github.Ptr(path),
+			StartLine:       github.Ptr(line),
+			EndLine:         github.Ptr(line),
+			AnnotationLevel: github.Ptr(annotationLevel(issue.Severity)),
+			Message:         github.Ptr(issue.Description),
+			Title:           github.Ptr(fmt.Sprintf("%s: %s", issue.Severity, issue.Name)),
+			RawDetails:      github.Ptr(issue.Link),
+		})
+	}
+
+	return annotations
+}
+
+// dependencyLine returns the 1-indexed line in a manifest's raw text that
+// mentions depName, falling back to false (so the caller can default to
+// line 1) when the manifest doesn't name it directly - e.g. a transitive
+// dependency FOSSA reported that isn't pinned in this file itself.
+func dependencyLine(raw, depName string) (int, bool) {
+	for i, line := range strings.Split(raw, "\n") {
+		if strings.Contains(line, depName) {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+func annotationLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "high", "critical":
+		return "failure"
+	case "medium", "moderate":
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+func (n *Notifier) publishStickyComment(ctx context.Context, client *github.Client, branch string, results []models.Result, previous *StoredScan) error {
+	prNumber, err := n.findOpenPR(ctx, client, branch)
+	if err != nil || prNumber == 0 {
+		return err
+	}
+
+	body := stickyCommentMarker + "\n" + renderSummary(results, previous)
+
+	comments, _, err := client.Issues.ListComments(ctx, n.config.Organization, n.config.Repository, prNumber, nil)
+	if err == nil {
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), stickyCommentMarker) {
+				_, _, editErr := client.Issues.EditComment(ctx, n.config.Organization, n.config.Repository, comment.GetID(), &github.IssueComment{Body: &body})
+				return editErr
+			}
+		}
+	}
+
+	_, _, err = client.Issues.CreateComment(ctx, n.config.Organization, n.config.Repository, prNumber, &github.IssueComment{Body: &body})
+	return err
+}
+
+func (n *Notifier) findOpenPR(ctx context.Context, client *github.Client, branch string) (int, error) {
+	if branch == "" {
+		return 0, nil
+	}
+
+	prs, _, err := client.PullRequests.List(ctx, n.config.Organization, n.config.Repository, &github.PullRequestListOptions{
+		Head:  fmt.Sprintf("%s:%s", n.config.Organization, branch),
+		State: "open",
+	})
+	if err != nil || len(prs) == 0 {
+		return 0, err
+	}
+
+	return prs[0].GetNumber(), nil
+}
+
+func renderSummary(results []models.Result, previous *StoredScan) string {
+	existing := make(map[string]bool)
+	if previous != nil {
+		for _, r := range previous.Results {
+			for _, issue := range r.Issues {
+				existing[issueKey(r.Project, issue)] = true
+			}
+		}
+	}
+
+	var totalIssues, newIssues int
+	var b strings.Builder
+	b.WriteString("## FOSSA-NX scan summary\n\n")
+	b.WriteString("| Project | Dependencies | Vulnerabilities | Status |\n")
+	b.WriteString("|---|---|---|---|\n")
+
+	for _, r := range results {
+		status := "✅ pass"
+		if r.Error != nil {
+			status = "❌ failed"
+		} else if len(r.Issues) > 0 {
+			status = "⚠️ issues"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %d | %d | %s |\n", r.Project, r.DependencyCount, len(r.Issues), status))
+
+		totalIssues += len(r.Issues)
+		for _, issue := range r.Issues {
+			if !existing[issueKey(r.Project, issue)] {
+				newIssues++
+			}
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\n**%d vulnerabilities total, %d new since this branch's last stored scan.**\n", totalIssues, newIssues))
+
+	return b.String()
+}
+
+func issueKey(project string, issue models.VulnerabilityIssue) string {
+	return project + "|" + issue.CVE + "|" + issue.Name
+}
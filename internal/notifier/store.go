@@ -0,0 +1,104 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// StoredScan is a single scan's results, persisted so later runs can diff
+// new findings against what was last reported for a branch.
+type StoredScan struct {
+	SavedAt time.Time       `json:"savedAt"`
+	Results []models.Result `json:"results"`
+}
+
+// Store persists scan results keyed by <repo>/<branch>/<commit> so the PR
+// comment notifier can compare the current run against the last scan
+// stored for the same branch.
+type Store interface {
+	Save(repo, branch, commit string, scan StoredScan) error
+	// Latest returns the most recently saved scan for repo/branch, or nil if
+	// none has been stored yet.
+	Latest(repo, branch string) (*StoredScan, error)
+}
+
+// FileStore is a filesystem-backed Store, one JSON file per commit under
+// <dir>/<repo>/<branch>/<commit>.json.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore rooted at dir (created on first Save).
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (s *FileStore) branchDir(repo, branch string) string {
+	return filepath.Join(s.dir, repo, branch)
+}
+
+// Save persists scan under <repo>/<branch>/<commit>.json.
+func (s *FileStore) Save(repo, branch, commit string, scan StoredScan) error {
+	dir := s.branchDir(repo, branch)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create store directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(scan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize stored scan: %w", err)
+	}
+
+	path := filepath.Join(dir, commit+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write stored scan %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Latest returns the most recently saved scan for repo/branch, determined by
+// each file's SavedAt timestamp.
+func (s *FileStore) Latest(repo, branch string) (*StoredScan, error) {
+	dir := s.branchDir(repo, branch)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store directory %s: %w", dir, err)
+	}
+
+	var scans []StoredScan
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		entryPath := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(entryPath)
+		if err != nil {
+			log.Printf("failed to read stored scan %s: %v", entryPath, err)
+			continue
+		}
+		var scan StoredScan
+		if err := json.Unmarshal(data, &scan); err != nil {
+			log.Printf("failed to parse stored scan %s, skipping: %v", entryPath, err)
+			continue
+		}
+		scans = append(scans, scan)
+	}
+
+	if len(scans) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(scans, func(i, j int) bool { return scans[i].SavedAt.After(scans[j].SavedAt) })
+	return &scans[0], nil
+}
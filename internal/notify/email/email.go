@@ -5,15 +5,41 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"image"
+	"image/color"
+	"image/png"
 	"log"
 	"net/smtp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jordan-wright/email"
 	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify"
 )
 
+// sortedByRisk returns issues ordered by EPSS score times severity rank,
+// descending, so the most urgent vulnerabilities (high confidence of
+// exploitation and high severity) are read first in the email report.
+func sortedByRisk(issues []models.VulnerabilityIssue) []models.VulnerabilityIssue {
+	sorted := make([]models.VulnerabilityIssue, len(issues))
+	copy(sorted, issues)
+
+	risk := func(issue models.VulnerabilityIssue) float64 {
+		score := issue.EPSSScore
+		if score == 0 {
+			score = 1 // no EPSS data: fall back to ranking by severity alone
+		}
+		return score * float64(notify.SeverityRank(issue.Severity))
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return risk(sorted[i]) > risk(sorted[j])
+	})
+	return sorted
+}
+
 // HTML Email Template
 const emailTemplate = `
 <!DOCTYPE html>
@@ -216,6 +242,28 @@ const emailTemplate = `
             </table>
         </div>
 
+        {{if .NewIssues}}
+            <div class="stats-block">
+                <h2>New Since Last Report</h2>
+                <ul>
+                {{range .NewIssues}}
+                    <li><strong>{{.Project}}</strong>: {{.Name}} ({{.Severity}}){{if .CVE}} &mdash; {{.CVE}}{{end}}{{if .Link}} &mdash; <a href="{{.Link}}" target="_blank">details</a>{{end}}</li>
+                {{end}}
+                </ul>
+            </div>
+        {{end}}
+
+        {{if .ResolvedIssues}}
+            <div class="stats-block">
+                <h2>Resolved Since Last Report</h2>
+                <ul>
+                {{range .ResolvedIssues}}
+                    <li><strong>{{.Project}}</strong>: {{.Name}} ({{.Severity}}){{if .CVE}} &mdash; {{.CVE}}{{end}}</li>
+                {{end}}
+                </ul>
+            </div>
+        {{end}}
+
         <h2>Vulnerabilities by Project</h2>
 
         {{if eq (len .ProjectsWithIssues) 0}}
@@ -234,10 +282,12 @@ const emailTemplate = `
                     <p><strong>Vulnerabilities:</strong> {{len .Issues}}</p>
                     
                     {{range .Issues}}
-                        <div class="vulnerability {{.Severity | ToLower}}">
+                        <div class="vulnerability {{.Severity | SeverityBucket}}">
                             <div class="vuln-name">
+                                {{if .KEV}}<span style="background-color: #000; color: #fff; padding: 2px 6px; border-radius: 3px; font-size: 11px;">KEV</span>{{end}}
                                 {{.Name}}
-                                <span class="severity-badge {{.Severity | ToLower}}-badge">{{.Severity}}</span>
+                                <img src="cid:{{.Severity | SeverityBucket}}-badge.png" width="48" height="16" alt="{{.Severity}}" style="vertical-align: middle;">
+                                <span class="severity-badge {{.Severity | SeverityBucket}}-badge">{{.Severity}}</span>
                             </div>
                             <div class="vuln-details">
                                 {{.Description}}
@@ -254,6 +304,17 @@ const emailTemplate = `
                                     <strong>First seen:</strong> {{.FirstSeen.Format "Jan 2, 2006"}}
                                 </div>
                             {{end}}
+                            {{if .PatchedVersions}}
+                                <div class="vuln-meta">
+                                    <strong>Patched versions:</strong> {{range $i, $v := .PatchedVersions}}{{if $i}}, {{end}}{{$v}}{{end}}
+                                </div>
+                            {{end}}
+                            {{if .EPSSScore}}
+                                <div class="vuln-meta">
+                                    <strong>EPSS:</strong> {{printf "%.1f%%" (mul .EPSSScore 100)}} likelihood of exploitation in the next 30 days
+                                    {{if .CVSSScore}} &middot; <strong>CVSS:</strong> {{printf "%.1f" .CVSSScore}}{{end}}
+                                </div>
+                            {{end}}
                             <div style="margin-top: 10px;">
                                 <a href="{{.Link}}" target="_blank" style="color: #0058a2;">View details</a>
                             </div>
@@ -271,6 +332,49 @@ const emailTemplate = `
 </html>
 `
 
+// severityBucket collapses a FOSSA severity string to one of "high",
+// "medium" or "low" so the template and the CID-embedded badge images only
+// need to handle three colors rather than every severity label FOSSA might
+// report.
+func severityBucket(severity string) string {
+	switch strings.ToLower(severity) {
+	case "high", "critical":
+		return "high"
+	case "medium", "moderate":
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// badgeColors gives the fill color for each severityBucket's CID-embedded
+// badge image, matching the corresponding CSS class in emailTemplate.
+var badgeColors = map[string]color.Color{
+	"high":   color.RGBA{R: 0xd9, G: 0x53, B: 0x4f, A: 0xff},
+	"medium": color.RGBA{R: 0xf0, G: 0xad, B: 0x4e, A: 0xff},
+	"low":    color.RGBA{R: 0x5b, G: 0xc0, B: 0xde, A: 0xff},
+}
+
+// badgePNG renders a small solid-color rectangle as a PNG, used as the
+// severity badge embedded via Content-ID rather than relying on a CSS
+// background-color, which aggressive spam filters and some mail clients
+// strip.
+func badgePNG(fill color.Color) ([]byte, error) {
+	const width, height = 48, 16
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // Parse comma-separated email list into a slice of email addresses
 func ParseEmailList(emails string) []string {
 	rawList := strings.Split(emails, ",")
@@ -286,6 +390,14 @@ func ParseEmailList(emails string) []string {
 	return result
 }
 
+// Finding is a vulnerability attributed to a project, used in the "new
+// since last report" / "resolved since last report" digest sections sent
+// by `fossa-nx daemon` (see internal/scheduler).
+type Finding struct {
+	Project string
+	models.VulnerabilityIssue
+}
+
 // Template data structure for the email report
 type TemplateData struct {
 	Date                   string
@@ -305,6 +417,12 @@ type TemplateData struct {
 	AverageDurationMinutes float64
 	MinDurationMinutes     float64
 	MaxDurationMinutes     float64
+
+	// NewIssues and ResolvedIssues are only populated for a scheduler
+	// digest report (SendDigestReport); a regular SendHTMLReport leaves
+	// them empty and the corresponding template sections are skipped.
+	NewIssues      []Finding
+	ResolvedIssues []Finding
 }
 
 // SendHTMLReport sends an HTML email report of FOSSA scan results
@@ -313,6 +431,25 @@ func SendHTMLReport(results []models.Result, config models.EmailConfig, verbose
 		return nil
 	}
 
+	data := buildTemplateData(results)
+	return deliver(data, config, verbose)
+}
+
+// SendDigestReport sends the same HTML/plaintext report as SendHTMLReport,
+// plus "new since last report" and "resolved since last report" sections
+// comparing this scan to the schedule entry's previous run.
+func SendDigestReport(results []models.Result, newIssues, resolvedIssues []Finding, config models.EmailConfig, verbose bool) error {
+	if !config.Enabled || len(config.ToEmails) == 0 {
+		return nil
+	}
+
+	data := buildTemplateData(results)
+	data.NewIssues = newIssues
+	data.ResolvedIssues = resolvedIssues
+	return deliver(data, config, verbose)
+}
+
+func buildTemplateData(results []models.Result) TemplateData {
 	// Count vulnerabilities by severity
 	var totalVulnerabilities, highSeverity, mediumSeverity, lowSeverity int
 	var projectsWithIssues []models.Result
@@ -328,6 +465,7 @@ func SendHTMLReport(results []models.Result, config models.EmailConfig, verbose
 
 		if len(result.Issues) > 0 {
 			totalVulnerabilities += len(result.Issues)
+			result.Issues = sortedByRisk(result.Issues)
 			projectsWithIssues = append(projectsWithIssues, result)
 
 			for _, issue := range result.Issues {
@@ -343,10 +481,6 @@ func SendHTMLReport(results []models.Result, config models.EmailConfig, verbose
 		}
 	}
 
-	if totalVulnerabilities == 0 && verbose {
-		log.Println("No vulnerabilities found, sending all-clear report")
-	}
-
 	// Calculate duration stats
 	var totalDuration time.Duration
 	minDuration := time.Hour * 24 // Initialize to a large value
@@ -416,9 +550,20 @@ func SendHTMLReport(results []models.Result, config models.EmailConfig, verbose
 		MaxDurationMinutes:     maxDuration.Minutes(),
 	}
 
+	return data
+}
+
+// deliver renders data through emailTemplate and sends it via SMTP.
+func deliver(data TemplateData, config models.EmailConfig, verbose bool) error {
+	if data.TotalVulnerabilities == 0 && verbose {
+		log.Println("No vulnerabilities found, sending all-clear report")
+	}
+
 	// Parse template with custom functions
 	funcMap := template.FuncMap{
-		"ToLower": strings.ToLower,
+		"ToLower":        strings.ToLower,
+		"SeverityBucket": severityBucket,
+		"mul":            func(a float64, b int) float64 { return a * float64(b) },
 	}
 
 	t, err := template.New("email").Funcs(funcMap).Parse(emailTemplate)
@@ -438,14 +583,30 @@ func SendHTMLReport(results []models.Result, config models.EmailConfig, verbose
 	e.To = config.ToEmails
 
 	// Set subject based on vulnerabilities found
-	if totalVulnerabilities > 0 {
+	if data.TotalVulnerabilities > 0 {
 		e.Subject = fmt.Sprintf("FOSSA Security Report: %d Vulnerabilities Found (%d High, %d Medium, %d Low)",
-			totalVulnerabilities, highSeverity, mediumSeverity, lowSeverity)
+			data.TotalVulnerabilities, data.HighSeverity, data.MediumSeverity, data.LowSeverity)
 	} else {
 		e.Subject = "FOSSA Security Report: No Vulnerabilities Detected"
 	}
 
 	e.HTML = body.Bytes()
+	e.Text = []byte(renderPlaintext(data))
+
+	// Embed the severity badges as multipart/related CID images rather
+	// than relying solely on the CSS background-color, which some mail
+	// clients and spam filters strip from HTML emails.
+	for _, bucket := range []string{"high", "medium", "low"} {
+		badge, err := badgePNG(badgeColors[bucket])
+		if err != nil {
+			return fmt.Errorf("failed to render %s severity badge: %v", bucket, err)
+		}
+		attachment, err := e.Attach(bytes.NewReader(badge), bucket+"-badge.png", "image/png")
+		if err != nil {
+			return fmt.Errorf("failed to attach %s severity badge: %v", bucket, err)
+		}
+		attachment.HTMLRelated = true
+	}
 
 	// Send email
 	if verbose {
@@ -0,0 +1,29 @@
+package email
+
+import (
+	"context"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify"
+)
+
+// Notifier adapts SendHTMLReport to the notify.Notifier interface, so email
+// can run alongside Slack/Teams/webhook/PagerDuty in the same --notify
+// dispatch loop instead of being special-cased in main.
+type Notifier struct {
+	config  models.EmailConfig
+	verbose bool
+}
+
+// NewNotifier returns a notify.Notifier that emails results via
+// SendHTMLReport. Notify is a no-op if config.Enabled is false.
+func NewNotifier(config models.EmailConfig, verbose bool) *Notifier {
+	return &Notifier{config: config, verbose: verbose}
+}
+
+func (n *Notifier) Notify(ctx context.Context, results []models.Result, summary notify.RunSummary) error {
+	if !n.config.Enabled {
+		return nil
+	}
+	return SendHTMLReport(results, n.config, n.verbose)
+}
@@ -0,0 +1,68 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderPlaintext builds a plain-text alternative for the HTML report from
+// the same TemplateData, so recipients on text-only clients (and spam
+// filters that penalize HTML-only mail) still get a readable summary: a
+// bulleted list of projects, their vulnerabilities grouped by severity, CVE
+// IDs, and links.
+func renderPlaintext(data TemplateData) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "FOSSA Security Report\nGenerated on %s\n\n", data.Date)
+
+	fmt.Fprintf(&b, "Summary\n")
+	fmt.Fprintf(&b, "  Total Projects Analyzed: %d\n", data.TotalProjects)
+	fmt.Fprintf(&b, "  Successful Scans: %d\n", data.SuccessfulProjects)
+	fmt.Fprintf(&b, "  Failed Scans: %d\n", data.FailedProjects)
+	fmt.Fprintf(&b, "  Total Vulnerabilities: %d\n", data.TotalVulnerabilities)
+	fmt.Fprintf(&b, "  High Severity: %d\n", data.HighSeverity)
+	fmt.Fprintf(&b, "  Medium Severity: %d\n", data.MediumSeverity)
+	fmt.Fprintf(&b, "  Low Severity: %d\n\n", data.LowSeverity)
+
+	b.WriteString("Vulnerabilities by Project\n")
+	if len(data.ProjectsWithIssues) == 0 {
+		b.WriteString("  No vulnerabilities were detected across all projects!\n")
+		return b.String()
+	}
+
+	for _, result := range data.ProjectsWithIssues {
+		fmt.Fprintf(&b, "\n* %s (%d dependencies, %d vulnerabilities)\n", result.Project, result.DependencyCount, len(result.Issues))
+		if result.FossaLink != "" {
+			fmt.Fprintf(&b, "  View in FOSSA: %s\n", result.FossaLink)
+		}
+
+		for _, bucket := range []string{"high", "medium", "low"} {
+			var inBucket []string
+			for _, issue := range result.Issues {
+				if severityBucket(issue.Severity) != bucket {
+					continue
+				}
+				line := fmt.Sprintf("  - [%s] %s", strings.ToUpper(issue.Severity), issue.Name)
+				if issue.KEV {
+					line += " [KEV]"
+				}
+				if issue.CVE != "" {
+					line += fmt.Sprintf(" (%s)", issue.CVE)
+				}
+				if issue.EPSSScore > 0 {
+					line += fmt.Sprintf(" epss=%.1f%%", issue.EPSSScore*100)
+				}
+				if issue.Link != "" {
+					line += fmt.Sprintf(" - %s", issue.Link)
+				}
+				inBucket = append(inBucket, line)
+			}
+			b.WriteString(strings.Join(inBucket, "\n"))
+			if len(inBucket) > 0 {
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	return b.String()
+}
@@ -6,16 +6,64 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/google/go-github/v71/github"
+	"github.com/kamalesh-seervi/fossa-nx/internal/ai"
 	"github.com/kamalesh-seervi/fossa-nx/internal/git"
 	"github.com/kamalesh-seervi/fossa-nx/internal/models"
 	"golang.org/x/oauth2"
 )
 
-// CreateIssues creates GitHub issues for vulnerabilities found in scan results
-func CreateIssues(results []models.Result, config models.GitHubConfig, verbose bool) error {
+// fossaLabel marks every issue fossa-nx files as its own, so reconciliation
+// only ever touches issues it created and never an unrelated open issue
+// that happens to match on title.
+const fossaLabel = "fossa"
+
+// fingerprintRegexp extracts the stable identity embedded as an HTML
+// comment in every issue body fossa-nx creates, e.g.
+// "<!-- fossa-nx: project=my-app cve=CVE-2024-1234 -->". HTML comments
+// aren't rendered, so the fingerprint survives round-tripping through
+// GitHub's markdown without affecting what a reviewer sees.
+var fingerprintRegexp = regexp.MustCompile(`<!-- fossa-nx: (.+?) -->`)
+
+// fingerprint identifies the same vulnerability across runs: the same
+// project, and the same CVE if one is known, falling back to the
+// vulnerability name since not every advisory has a CVE assigned. This
+// mirrors scheduler.issueKey's matching rule, so the daemon's "new since
+// last digest" and GitHub's "same issue, don't refile" agree on identity.
+func fingerprint(project string, vuln models.VulnerabilityIssue) string {
+	id := vuln.CVE
+	if id == "" {
+		id = vuln.Name
+	}
+	return fmt.Sprintf("project=%s cve=%s", project, id)
+}
+
+func formatFingerprintComment(fp string) string {
+	return fmt.Sprintf("<!-- fossa-nx: %s -->", fp)
+}
+
+// parseFingerprint extracts the fingerprint embedded in an issue body fossa-nx
+// created, if any.
+func parseFingerprint(body string) (string, bool) {
+	match := fingerprintRegexp.FindStringSubmatch(body)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// CreateIssues reconciles GitHub issues against the vulnerabilities found in
+// results: existing open FOSSA issues are matched to current findings by
+// fingerprint (see fingerprint) and updated in place rather than refiled,
+// new findings get a new issue, and - if config.AutoCloseResolved is set -
+// any open FOSSA issue whose fingerprint no longer appears in results gets
+// a "resolved" comment and is closed. aiClient drafts an optional
+// "AI-assisted triage" section appended to each issue body; pass
+// ai.NoopClient{} to skip it.
+func CreateIssues(results []models.Result, config models.GitHubConfig, aiClient ai.Client, verbose bool) error {
 	if !config.Enabled {
 		return nil
 	}
@@ -71,35 +119,197 @@ func CreateIssues(results []models.Result, config models.GitHubConfig, verbose b
 	}
 
 	if verbose {
-		log.Printf("Creating GitHub issues for %d vulnerabilities", totalIssues)
+		log.Printf("Reconciling GitHub issues for %d vulnerabilities", totalIssues)
+	}
+
+	openIssues, err := listOpenFossaIssues(ctx, client, config)
+	if err != nil {
+		return fmt.Errorf("error listing open FOSSA issues: %w", err)
+	}
+
+	openByFingerprint := make(map[string]*github.Issue, len(openIssues))
+	for _, issue := range openIssues {
+		if fp, ok := parseFingerprint(issue.GetBody()); ok {
+			openByFingerprint[fp] = issue
+		}
 	}
 
-	// Track created issues to avoid duplicates
-	issuesCreated := 0
+	var issuesCreated, issuesUpdated int
 
-	// Process each result
 	for _, result := range results {
 		if result.Error != nil || len(result.Issues) == 0 {
 			continue
 		}
 
-		// Create issues for each vulnerability
 		for _, vuln := range result.Issues {
-			// Create issue title
-			issueTitle := fmt.Sprintf("[FOSSA] %s: %s vulnerability in %s",
-				vuln.Severity, vuln.Name, result.Project)
-
-			// Create issue with detailed information
-			var cveInfo string
-			if vuln.CVE != "" {
-				cveInfo = fmt.Sprintf("**CVE:** %s", vuln.CVE)
+			fp := fingerprint(result.Project, vuln)
+			title, body, labels := buildIssueContent(ctx, result, vuln, fp, aiClient, verbose)
+
+			if existing, ok := openByFingerprint[fp]; ok {
+				delete(openByFingerprint, fp)
+
+				if existing.GetBody() == body && sameLabels(existing.Labels, labels) {
+					continue
+				}
+
+				_, _, err := client.Issues.Edit(ctx, config.Organization, config.Repository, existing.GetNumber(), &github.IssueRequest{
+					Title:  &title,
+					Body:   &body,
+					Labels: &labels,
+				})
+				if err != nil {
+					log.Printf("Error updating GitHub issue #%d: %v", existing.GetNumber(), err)
+					continue
+				}
+
+				issuesUpdated++
+				if verbose {
+					log.Printf("Updated GitHub issue #%d: %s", existing.GetNumber(), title)
+				}
+				continue
+			}
+
+			issue := &github.IssueRequest{
+				Title:  &title,
+				Body:   &body,
+				Labels: &labels,
+			}
+
+			_, _, err := client.Issues.Create(ctx, config.Organization, config.Repository, issue)
+			if err != nil {
+				log.Printf("Error creating GitHub issue: %v", err)
+				continue
+			}
+
+			issuesCreated++
+			if verbose {
+				log.Printf("Created GitHub issue: %s", title)
 			}
+		}
+	}
+
+	issuesClosed := 0
+	if config.AutoCloseResolved {
+		issuesClosed = closeResolvedIssues(ctx, client, config, openByFingerprint, verbose)
+	}
+
+	if verbose {
+		log.Printf("GitHub issues: %d created, %d updated, %d closed", issuesCreated, issuesUpdated, issuesClosed)
+	}
+
+	return nil
+}
+
+// listOpenFossaIssues fetches every open issue fossa-nx is responsible for,
+// i.e. labeled fossaLabel, paging through all results.
+func listOpenFossaIssues(ctx context.Context, client *github.Client, config models.GitHubConfig) ([]*github.Issue, error) {
+	var all []*github.Issue
+
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      []string{fossaLabel},
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		issues, resp, err := client.Issues.ListByRepo(ctx, config.Organization, config.Repository, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, issues...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// closeResolvedIssues closes every issue remaining in stale (i.e. every
+// still-open FOSSA issue whose fingerprint wasn't matched to a current
+// finding), posting a "resolved" comment first.
+func closeResolvedIssues(ctx context.Context, client *github.Client, config models.GitHubConfig, stale map[string]*github.Issue, verbose bool) int {
+	closed := 0
+
+	for _, issue := range stale {
+		comment := &github.IssueComment{
+			Body: github.Ptr("This vulnerability is no longer detected by fossa-nx and is being closed as resolved."),
+		}
+		if _, _, err := client.Issues.CreateComment(ctx, config.Organization, config.Repository, issue.GetNumber(), comment); err != nil {
+			log.Printf("Error commenting on resolved GitHub issue #%d: %v", issue.GetNumber(), err)
+			continue
+		}
 
-			issueBody := fmt.Sprintf(`
+		state := "closed"
+		if _, _, err := client.Issues.Edit(ctx, config.Organization, config.Repository, issue.GetNumber(), &github.IssueRequest{State: &state}); err != nil {
+			log.Printf("Error closing resolved GitHub issue #%d: %v", issue.GetNumber(), err)
+			continue
+		}
+
+		closed++
+		if verbose {
+			log.Printf("Closed resolved GitHub issue #%d", issue.GetNumber())
+		}
+	}
+
+	return closed
+}
+
+// FindTrackingIssue returns the number of the open FOSSA issue matching
+// project/vuln's fingerprint - the one CreateIssues filed or updated for
+// it - so a remediation PR can link back to it. ok is false if no such
+// issue is currently open.
+func FindTrackingIssue(ctx context.Context, client *github.Client, config models.GitHubConfig, project string, vuln models.VulnerabilityIssue) (number int, ok bool, err error) {
+	issues, err := listOpenFossaIssues(ctx, client, config)
+	if err != nil {
+		return 0, false, err
+	}
+
+	fp := fingerprint(project, vuln)
+	for _, issue := range issues {
+		if existing, ok := parseFingerprint(issue.GetBody()); ok && existing == fp {
+			return issue.GetNumber(), true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// CommentOnTrackingIssue posts message as a comment on the open FOSSA issue
+// matching project/vuln's fingerprint, if one exists. It's a no-op (not an
+// error) when no tracking issue is open, so a remediation failure for a
+// vulnerability nobody filed an issue for doesn't itself fail.
+func CommentOnTrackingIssue(ctx context.Context, client *github.Client, config models.GitHubConfig, project string, vuln models.VulnerabilityIssue, message string) error {
+	number, ok, err := FindTrackingIssue(ctx, client, config, project, vuln)
+	if err != nil || !ok {
+		return err
+	}
+
+	_, _, err = client.Issues.CreateComment(ctx, config.Organization, config.Repository, number, &github.IssueComment{Body: github.Ptr(message)})
+	return err
+}
+
+// buildIssueContent renders vuln's title, body, and labels, embedding fp as
+// an HTML comment in the body so a later run can recognize and update this
+// same issue instead of refiling it. If aiClient drafts a summary or
+// remediation, it's appended in a clearly-marked section so reviewers can
+// distinguish it from raw FOSSA data.
+func buildIssueContent(ctx context.Context, result models.Result, vuln models.VulnerabilityIssue, fp string, aiClient ai.Client, verbose bool) (title, body string, labels []string) {
+	title = fmt.Sprintf("[FOSSA] %s: %s vulnerability in %s", vuln.Severity, vuln.Name, result.Project)
+
+	var cveInfo string
+	if vuln.CVE != "" {
+		cveInfo = fmt.Sprintf("**CVE:** %s", vuln.CVE)
+	}
+
+	body = fmt.Sprintf(`%s
 ## FOSSA Vulnerability Report
 
-**Project:** %s  
-**Vulnerability:** %s  
+**Project:** %s
+**Vulnerability:** %s
 **Severity:** %s
 
 ### Description
@@ -108,64 +318,77 @@ func CreateIssues(results []models.Result, config models.GitHubConfig, verbose b
 %s
 
 ### Vulnerability Details
-`, result.Project, vuln.Name, vuln.Severity, vuln.Description, cveInfo)
+`, formatFingerprintComment(fp), result.Project, vuln.Name, vuln.Severity, vuln.Description, cveInfo)
 
-			// Add fixed version info if available
-			if vuln.FixedIn != "" {
-				issueBody += fmt.Sprintf("\n**Fixed in:** %s", vuln.FixedIn)
-			}
+	// Add fixed version info if available
+	if vuln.FixedIn != "" {
+		body += fmt.Sprintf("\n**Fixed in:** %s", vuln.FixedIn)
+	}
 
-			// Add discovery date
-			if !vuln.FirstSeen.IsZero() {
-				issueBody += fmt.Sprintf("\n**First discovered:** %s", vuln.FirstSeen.Format("Jan 2, 2006"))
-			}
+	// Add discovery date
+	if !vuln.FirstSeen.IsZero() {
+		body += fmt.Sprintf("\n**First discovered:** %s", vuln.FirstSeen.Format("Jan 2, 2006"))
+	}
 
-			// Add links
-			issueBody += fmt.Sprintf(`
+	// Add links
+	body += fmt.Sprintf(`
 
 ### Links
 - [View in FOSSA](%s)
 - [Vulnerability details](%s)
-
----
-*This issue was automatically created by fossa-nx*
 `, result.FossaLink, vuln.Link)
 
-			// Create labels based on severity
-			labels := []string{"security", "fossa", "vulnerability"}
-			switch strings.ToLower(vuln.Severity) {
-			case "high", "critical":
-				labels = append(labels, "severity:high")
-			case "medium", "moderate":
-				labels = append(labels, "severity:medium")
-			case "low":
-				labels = append(labels, "severity:low")
-			}
+	if summary, remediation, err := aiClient.DraftIssue(ctx, result.Project, vuln); err != nil {
+		if verbose {
+			log.Printf("AI triage draft failed for %s/%s: %v", result.Project, vuln.Name, err)
+		}
+	} else if summary != "" || remediation != "" {
+		body += "\n### AI-assisted triage (review before acting)\n"
+		if summary != "" {
+			body += fmt.Sprintf("\n%s\n", summary)
+		}
+		if remediation != "" {
+			body += fmt.Sprintf("\n**Suggested remediation:**\n```\n%s\n```\n", remediation)
+		}
+	}
 
-			issue := &github.IssueRequest{
-				Title:  &issueTitle,
-				Body:   &issueBody,
-				Labels: &labels,
-			}
+	body += `
+---
+*This issue is automatically managed by fossa-nx*
+`
+
+	labels = []string{"security", fossaLabel, "vulnerability"}
+	switch strings.ToLower(vuln.Severity) {
+	case "high", "critical":
+		labels = append(labels, "severity:high")
+	case "medium", "moderate":
+		labels = append(labels, "severity:medium")
+	case "low":
+		labels = append(labels, "severity:low")
+	}
 
-			_, _, err := client.Issues.Create(ctx, config.Organization, config.Repository, issue)
-			if err != nil {
-				log.Printf("Error creating GitHub issue: %v", err)
-				continue
-			}
+	return title, body, labels
+}
 
-			issuesCreated++
-			if verbose {
-				log.Printf("Created GitHub issue: %s", issueTitle)
-			}
-		}
+// sameLabels reports whether existing's labels are the same set as labels,
+// ignoring order, so an unchanged issue isn't re-edited on every run.
+func sameLabels(existing []*github.Label, labels []string) bool {
+	if len(existing) != len(labels) {
+		return false
 	}
 
-	if verbose {
-		log.Printf("Created %d GitHub issues", issuesCreated)
+	existingSet := make(map[string]bool, len(existing))
+	for _, l := range existing {
+		existingSet[l.GetName()] = true
 	}
 
-	return nil
+	for _, l := range labels {
+		if !existingSet[l] {
+			return false
+		}
+	}
+
+	return true
 }
 
 // CreateCommitStatus creates a commit status check for FOSSA scan results
@@ -0,0 +1,118 @@
+// Package notify defines the generalized notification contract shared by
+// every notification backend (internal/notify/email, internal/notify/slack,
+// internal/notify/teams, internal/notify/webhook,
+// internal/notify/pagerduty), so a scan's results can be delivered to an
+// arbitrary number of external systems the same way regardless of channel,
+// each run concurrently; Slack, Teams and webhook notifiers can each be
+// gated by their own severity threshold (see FilterBySeverity). The
+// GitHub integration (internal/notify/github) predates this package and
+// keeps its own dedicated flags and config struct in models, since it does
+// more than deliver a notification (issue dedup, commit statuses, PR
+// comments).
+package notify
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/report"
+)
+
+// RunSummary is the same schema-versioned run-summary event used by
+// --output=json/ndjson, reused here so the webhook notifier's payload
+// matches that schema rather than inventing a parallel shape.
+type RunSummary = report.RunSummaryEvent
+
+// Notifier delivers a finished scan's results to some external system.
+// Notify is called once per run, after every project has completed.
+type Notifier interface {
+	Notify(ctx context.Context, results []models.Result, summary RunSummary) error
+}
+
+// Channel names recognized by the --notify flag.
+const (
+	ChannelEmail     = "email"
+	ChannelSlack     = "slack"
+	ChannelTeams     = "teams"
+	ChannelWebhook   = "webhook"
+	ChannelPagerDuty = "pagerduty"
+)
+
+// ParseChannels splits a comma-separated --notify value into trimmed,
+// lower-cased, non-empty channel names.
+func ParseChannels(value string) []string {
+	var channels []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part != "" {
+			channels = append(channels, part)
+		}
+	}
+	return channels
+}
+
+// Has reports whether channel appears in channels.
+func Has(channels []string, channel string) bool {
+	for _, c := range channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// severityRank orders severities from low to high so callers can compare
+// two severities, or filter below a threshold, without re-declaring the
+// same switch in every notifier.
+var severityRank = map[string]int{
+	"low":      1,
+	"medium":   2,
+	"moderate": 2,
+	"high":     3,
+	"critical": 4,
+}
+
+// SeverityRank returns severity's rank (1=low .. 4=critical), or 0 if it
+// isn't one of the severities FOSSA reports.
+func SeverityRank(severity string) int {
+	return severityRank[strings.ToLower(severity)]
+}
+
+// FilterBySeverity returns results with each project's Issues narrowed to
+// those at or above minSeverity, so e.g. a Slack notifier can be
+// configured to only act on critical findings while email still gets
+// everything. Projects with no issues left after filtering are dropped;
+// failed projects are always kept so notifiers still surface scan
+// failures. An empty or unrecognized minSeverity returns results
+// unchanged.
+func FilterBySeverity(results []models.Result, minSeverity string) []models.Result {
+	threshold := SeverityRank(minSeverity)
+	if threshold == 0 {
+		return results
+	}
+
+	filtered := make([]models.Result, 0, len(results))
+	for _, result := range results {
+		if result.Error != nil {
+			filtered = append(filtered, result)
+			continue
+		}
+
+		var issues []models.VulnerabilityIssue
+		for _, issue := range result.Issues {
+			if SeverityRank(issue.Severity) >= threshold {
+				issues = append(issues, issue)
+			}
+		}
+		if len(issues) == 0 {
+			continue
+		}
+
+		kept := result
+		kept.Issues = issues
+		filtered = append(filtered, kept)
+	}
+
+	return filtered
+}
@@ -0,0 +1,111 @@
+// Package pagerduty triggers a PagerDuty Events v2 incident from a scan's
+// results, but only when the number of high/critical severity
+// vulnerabilities found exceeds a configured threshold - unlike the other
+// notify backends, most runs shouldn't page anyone.
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify"
+)
+
+// eventsEndpoint is the PagerDuty Events API v2 ingest URL.
+const eventsEndpoint = "https://events.pagerduty.com/v2/enqueue"
+
+// Config configures the PagerDuty notifier.
+type Config struct {
+	RoutingKey string
+	// Threshold is the number of high/critical severity issues a run must
+	// have before an incident is triggered. A run with Threshold or fewer
+	// does not page.
+	Threshold int
+	Enabled   bool
+}
+
+// Notifier triggers a PagerDuty Events v2 incident.
+type Notifier struct {
+	config Config
+	client *http.Client
+}
+
+// New returns a Notifier bound to config. Notify is a no-op if
+// config.Enabled is false.
+func New(config Config) *Notifier {
+	return &Notifier{config: config, client: &http.Client{Timeout: 15 * time.Second}}
+}
+
+func (n *Notifier) Notify(ctx context.Context, results []models.Result, summary notify.RunSummary) error {
+	if !n.config.Enabled {
+		return nil
+	}
+
+	count := highSeverityCount(results)
+	if count <= n.config.Threshold {
+		return nil
+	}
+
+	body, err := json.Marshal(triggerEvent{
+		RoutingKey:  n.config.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    "fossa-nx-scan",
+		Payload: eventPayload{
+			Summary:  fmt.Sprintf("fossa-nx: %d high/critical vulnerabilities found (threshold %d)", count, n.config.Threshold),
+			Source:   "fossa-nx",
+			Severity: "critical",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode pagerduty event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, eventsEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to trigger pagerduty event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("pagerduty events API returned %s", resp.Status)
+	}
+	return nil
+}
+
+type triggerEvent struct {
+	RoutingKey  string       `json:"routing_key"`
+	EventAction string       `json:"event_action"`
+	DedupKey    string       `json:"dedup_key,omitempty"`
+	Payload     eventPayload `json:"payload"`
+}
+
+type eventPayload struct {
+	Summary  string `json:"summary"`
+	Source   string `json:"source"`
+	Severity string `json:"severity"`
+}
+
+func highSeverityCount(results []models.Result) int {
+	count := 0
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			switch strings.ToLower(issue.Severity) {
+			case "high", "critical":
+				count++
+			}
+		}
+	}
+	return count
+}
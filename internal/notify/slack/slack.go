@@ -0,0 +1,156 @@
+// Package slack posts a scan summary to a Slack incoming webhook as a Block
+// Kit message, with one severity-coloured attachment per project that found
+// issues so reviewers can drill down without leaving Slack.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify"
+)
+
+// Config configures the Slack notifier.
+type Config struct {
+	WebhookURL string
+	Enabled    bool
+}
+
+// Notifier posts to a Slack incoming webhook.
+type Notifier struct {
+	config Config
+	client *http.Client
+}
+
+// New returns a Notifier bound to config. Notify is a no-op if
+// config.Enabled is false.
+func New(config Config) *Notifier {
+	return &Notifier{config: config, client: &http.Client{Timeout: 15 * time.Second}}
+}
+
+func (n *Notifier) Notify(ctx context.Context, results []models.Result, summary notify.RunSummary) error {
+	if !n.config.Enabled {
+		return nil
+	}
+
+	body, err := json.Marshal(buildMessage(results, summary))
+	if err != nil {
+		return fmt.Errorf("failed to encode slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to slack webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// message is a Slack incoming-webhook payload: a Block Kit body plus legacy
+// "attachments" for the severity colour bar, which blocks alone can't draw.
+type message struct {
+	Blocks      []block      `json:"blocks"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+type block struct {
+	Type string     `json:"type"`
+	Text *blockText `json:"text,omitempty"`
+}
+
+type blockText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type attachment struct {
+	Color  string  `json:"color"`
+	Blocks []block `json:"blocks"`
+}
+
+func section(text string) block {
+	return block{Type: "section", Text: &blockText{Type: "mrkdwn", Text: text}}
+}
+
+func buildMessage(results []models.Result, summary notify.RunSummary) message {
+	header := fmt.Sprintf("*FOSSA-NX scan complete* — %d/%d projects succeeded, %d vulnerabilities found",
+		summary.Successful, summary.TotalProjects, summary.Vulnerabilities)
+
+	msg := message{
+		Blocks: []block{
+			section(header),
+		},
+	}
+
+	for _, result := range results {
+		if result.Error == nil && len(result.Issues) == 0 {
+			continue
+		}
+		msg.Attachments = append(msg.Attachments, projectAttachment(result))
+	}
+
+	return msg
+}
+
+func projectAttachment(result models.Result) attachment {
+	if result.Error != nil {
+		return attachment{
+			Color:  "#cc0000",
+			Blocks: []block{section(fmt.Sprintf("*%s*: scan failed — %v", result.Project, result.Error))},
+		}
+	}
+
+	var lines []string
+	for _, issue := range result.Issues {
+		lines = append(lines, fmt.Sprintf("• *%s* (%s) <%s|%s>", issue.Name, issue.Severity, issue.Link, issue.CVE))
+	}
+
+	return attachment{
+		Color: colorForSeverity(highestSeverity(result.Issues)),
+		Blocks: []block{
+			section(fmt.Sprintf("*%s* — %d vulnerabilities", result.Project, len(result.Issues))),
+			section(strings.Join(lines, "\n")),
+		},
+	}
+}
+
+func highestSeverity(issues []models.VulnerabilityIssue) string {
+	highest := ""
+	highestRank := -1
+	for _, issue := range issues {
+		if r := notify.SeverityRank(issue.Severity); r > highestRank {
+			highest = issue.Severity
+			highestRank = r
+		}
+	}
+	return highest
+}
+
+func colorForSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return "#cc0000"
+	case "medium", "moderate":
+		return "#e6a700"
+	case "low":
+		return "#2eb886"
+	default:
+		return "#808080"
+	}
+}
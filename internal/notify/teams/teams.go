@@ -0,0 +1,151 @@
+// Package teams posts a scan summary to a Microsoft Teams incoming webhook
+// as an Adaptive Card: a fact set with the run's totals, then one text
+// block per project that found issues or failed to scan.
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify"
+)
+
+// Config configures the Microsoft Teams notifier.
+type Config struct {
+	WebhookURL string
+	Enabled    bool
+}
+
+// Notifier posts to a Teams incoming webhook.
+type Notifier struct {
+	config Config
+	client *http.Client
+}
+
+// New returns a Notifier bound to config. Notify is a no-op if
+// config.Enabled is false.
+func New(config Config) *Notifier {
+	return &Notifier{config: config, client: &http.Client{Timeout: 15 * time.Second}}
+}
+
+func (n *Notifier) Notify(ctx context.Context, results []models.Result, summary notify.RunSummary) error {
+	if !n.config.Enabled {
+		return nil
+	}
+
+	body, err := json.Marshal(buildPayload(results, summary))
+	if err != nil {
+		return fmt.Errorf("failed to encode teams message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to teams webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("teams webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// messagePayload wraps an Adaptive Card the way a Teams incoming webhook
+// connector expects it.
+type messagePayload struct {
+	Type        string       `json:"type"`
+	Attachments []attachment `json:"attachments"`
+}
+
+type attachment struct {
+	ContentType string                 `json:"contentType"`
+	Content     map[string]interface{} `json:"content"`
+}
+
+func buildPayload(results []models.Result, summary notify.RunSummary) messagePayload {
+	body := []map[string]interface{}{
+		{
+			"type":   "TextBlock",
+			"text":   "FOSSA-NX scan complete",
+			"weight": "bolder",
+			"size":   "medium",
+		},
+		{
+			"type": "FactSet",
+			"facts": []map[string]string{
+				{"title": "Projects", "value": fmt.Sprintf("%d/%d succeeded", summary.Successful, summary.TotalProjects)},
+				{"title": "Vulnerabilities", "value": fmt.Sprintf("%d", summary.Vulnerabilities)},
+				{"title": "Avg duration", "value": fmt.Sprintf("%dms", summary.AvgDurationMs)},
+			},
+		},
+	}
+
+	for _, result := range results {
+		if result.Error == nil && len(result.Issues) == 0 {
+			continue
+		}
+		body = append(body, projectBlock(result))
+	}
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+
+	return messagePayload{
+		Type: "message",
+		Attachments: []attachment{
+			{ContentType: "application/vnd.microsoft.card.adaptive", Content: card},
+		},
+	}
+}
+
+func projectBlock(result models.Result) map[string]interface{} {
+	if result.Error != nil {
+		return map[string]interface{}{
+			"type":  "TextBlock",
+			"text":  fmt.Sprintf("**%s**: scan failed — %v", result.Project, result.Error),
+			"wrap":  true,
+			"color": "attention",
+		}
+	}
+
+	var lines []string
+	for _, issue := range result.Issues {
+		lines = append(lines, fmt.Sprintf("- %s (%s): %s", issue.Name, issue.Severity, issue.Link))
+	}
+
+	return map[string]interface{}{
+		"type":  "TextBlock",
+		"text":  fmt.Sprintf("**%s** — %d vulnerabilities\n\n%s", result.Project, len(result.Issues), strings.Join(lines, "\n")),
+		"wrap":  true,
+		"color": colorForSeverity(result.Issues),
+	}
+}
+
+func colorForSeverity(issues []models.VulnerabilityIssue) string {
+	for _, issue := range issues {
+		switch strings.ToLower(issue.Severity) {
+		case "critical", "high":
+			return "attention"
+		}
+	}
+	if len(issues) > 0 {
+		return "warning"
+	}
+	return "default"
+}
@@ -0,0 +1,119 @@
+// Package webhook delivers scan results to an arbitrary HTTP endpoint as
+// the same schema-versioned JSON used by --output=json, so users can point
+// it at their own dashboards or serverless functions instead of one of the
+// built-in chat integrations.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify"
+	"github.com/kamalesh-seervi/fossa-nx/internal/report"
+)
+
+// maxAttempts and initialBackoff bound the retry loop in Notify: 4 attempts
+// with a doubling delay give a failing endpoint roughly 3.5s to recover
+// before the run gives up on it.
+const (
+	maxAttempts    = 4
+	initialBackoff = 500 * time.Millisecond
+)
+
+// Config configures the generic outbound webhook notifier.
+type Config struct {
+	URL     string
+	Secret  string // if set, signs the payload with HMAC-SHA256
+	Enabled bool
+}
+
+// Notifier POSTs a JSON payload of report.ProjectResultEvent/RunSummaryEvent
+// to Config.URL, retrying with exponential backoff on failure.
+type Notifier struct {
+	config Config
+	client *http.Client
+}
+
+// New returns a Notifier bound to config. Notify is a no-op if
+// config.Enabled is false.
+func New(config Config) *Notifier {
+	return &Notifier{config: config, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// payload mirrors the schema-versioned events report.Reporter emits in
+// --output=json mode, so a webhook consumer can share parsing code with
+// that output mode.
+type payload struct {
+	Results []report.ProjectResultEvent `json:"results"`
+	Summary notify.RunSummary           `json:"summary"`
+}
+
+func (n *Notifier) Notify(ctx context.Context, results []models.Result, summary notify.RunSummary) error {
+	if !n.config.Enabled {
+		return nil
+	}
+
+	events := make([]report.ProjectResultEvent, 0, len(results))
+	for _, result := range results {
+		events = append(events, report.ProjectEvent(result))
+	}
+
+	body, err := json.Marshal(payload{Results: events, Summary: summary})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	var lastErr error
+	backoff := initialBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err := n.post(ctx, body); err != nil {
+			lastErr = err
+			log.Printf("webhook delivery attempt %d/%d to %s failed: %v", attempt, maxAttempts, n.config.URL, err)
+			if attempt < maxAttempts {
+				time.Sleep(backoff)
+				backoff *= 2
+			}
+			continue
+		}
+		return nil
+	}
+
+	return fmt.Errorf("webhook delivery to %s failed after %d attempts: %w", n.config.URL, maxAttempts, lastErr)
+}
+
+func (n *Notifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Fossa-Nx-Schema", report.Schema)
+
+	if n.config.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.config.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Fossa-Nx-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint returned %s", resp.Status)
+	}
+	return nil
+}
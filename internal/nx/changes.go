@@ -3,139 +3,221 @@ package nx
 import (
 	"encoding/json"
 	"fmt"
+	"log"
 	"os"
 	"os/exec"
 	"strings"
 	"sync"
 )
 
-// Cache for project graph and changed files
+// Cache for the project graph and changed files
 var (
-	projectGraphCache map[string]ProjectNode
-	projectGraphOnce  sync.Once
-	changedFilesCache []string
-	changedFilesOnce  sync.Once
+	projectGraph     *ProjectGraph
+	projectGraphOnce sync.Once
+	projectGraphErr  error
+	graphFilePath    string
+
+	// graphCacheDir and graphCacheDisabled mirror the --cache-dir/--no-cache
+	// flags already used by the scan-result cache (internal/cache); the
+	// project graph cache reuses them rather than adding its own.
+	graphCacheDir      string
+	graphCacheDisabled bool
+
+	changedFilesMu    sync.Mutex
+	changedFilesCache = map[string][]string{}
 )
 
-// ProjectNode represents a project in the NX workspace
+// ProjectNode represents a single project in the NX workspace graph.
 type ProjectNode struct {
 	Name string `json:"name"`
+	Type string `json:"type"`
 	Data struct {
-		Root string `json:"root"`
+		Root       string                 `json:"root"`
+		SourceRoot string                 `json:"sourceRoot"`
+		Tags       []string               `json:"tags"`
+		Targets    map[string]interface{} `json:"targets"`
 	} `json:"data"`
 }
 
-// ProjectGraph represents the NX project graph structure
+// DependencyEdge is one edge of the NX project graph's dependency map.
+type DependencyEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// ProjectGraph is the full NX project graph: every project's metadata plus
+// its dependency edges, loaded once per process by LoadProjectGraph.
 type ProjectGraph struct {
-	Nodes map[string]ProjectNode `json:"nodes"`
+	Nodes        map[string]ProjectNode      `json:"nodes"`
+	Dependencies map[string][]DependencyEdge `json:"dependencies"`
 }
 
-// GetChangedFiles returns files that changed between base and head commits
-func GetChangedFiles(base, head string) ([]string, error) {
-	// In CI environments, we typically run with the same base/head for the entire process
-	// so simple sync.Once caching is sufficient
-	var err error
-	changedFilesOnce.Do(func() {
-		// Build git diff command
-		var cmd *exec.Cmd
-		if base != "" && head != "" {
-			cmd = exec.Command("git", "diff", "--name-only", fmt.Sprintf("%s..%s", base, head))
-		} else if base != "" {
-			cmd = exec.Command("git", "diff", "--name-only", base)
-		} else {
-			// Default to uncommitted changes
-			cmd = exec.Command("git", "diff", "--name-only", "HEAD")
-		}
+// SetGraphFile configures LoadProjectGraph to read a pre-generated graph
+// from path (the --graph-file flag) instead of invoking `nx graph` itself,
+// so CI can generate the graph once and share it across parallel jobs.
+func SetGraphFile(path string) {
+	graphFilePath = path
+}
 
-		output, cmdErr := cmd.Output()
-		if cmdErr != nil {
-			err = fmt.Errorf("failed to get changed files: %w", cmdErr)
-			return
-		}
+// SetGraphCacheDir configures where the on-disk project graph cache (keyed
+// by a hash of nx.json, every project.json, and the root package.json/
+// lockfiles) is stored. Empty uses $XDG_CACHE_HOME/fossa-nx (or
+// ~/.cache/fossa-nx). Mirrors the scan-result cache's --cache-dir flag.
+func SetGraphCacheDir(dir string) {
+	graphCacheDir = dir
+}
 
-		outputStr := strings.TrimSpace(string(output))
+// SetGraphCacheDisabled disables the on-disk project graph cache (the
+// --no-cache flag), forcing LoadProjectGraph to always regenerate the graph.
+func SetGraphCacheDisabled(disabled bool) {
+	graphCacheDisabled = disabled
+}
 
-		// If no changes, return empty slice immediately
-		if outputStr == "" {
-			changedFilesCache = []string{}
-			return
+// LoadProjectGraph runs `nx graph --file=<tmp>.json` (or reads the file
+// configured via SetGraphFile) exactly once per process and hydrates an
+// in-memory ProjectGraph. GetProjectRoot, GetProjects, and
+// CreateTemporaryPackageJson all consult this graph instead of shelling out
+// to `yarn nx` per project.
+func LoadProjectGraph() (*ProjectGraph, error) {
+	projectGraphOnce.Do(func() {
+		projectGraph, projectGraphErr = loadProjectGraph()
+	})
+	return projectGraph, projectGraphErr
+}
+
+func loadProjectGraph() (*ProjectGraph, error) {
+	// graphFilePath is already an explicit, pre-generated snapshot (the
+	// --graph-file flag); caching it again on disk would just add a layer
+	// of indirection around a file the caller already controls.
+	if graphFilePath == "" && !graphCacheDisabled {
+		if graph, ok := loadCachedGraph(graphCacheDir); ok {
+			return graph, nil
 		}
+	}
 
-		changedFiles := []string{}
-		lines := strings.Split(outputStr, "\n")
+	output, err := readGraphOutput()
+	if err != nil {
+		return nil, err
+	}
 
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line != "" {
-				// Normalize path separators for cross-platform compatibility
-				changedFiles = append(changedFiles, strings.ReplaceAll(line, "\\", "/"))
-			}
+	var graph ProjectGraph
+	if err := json.Unmarshal(output, &graph); err != nil {
+		return nil, fmt.Errorf("failed to parse project graph: %w", err)
+	}
+
+	if graphFilePath == "" && !graphCacheDisabled {
+		if err := saveCachedGraph(graphCacheDir, &graph); err != nil {
+			log.Printf("failed to persist project graph cache: %v", err)
 		}
+	}
 
-		changedFilesCache = changedFiles
-	})
+	return &graph, nil
+}
 
+func readGraphOutput() ([]byte, error) {
+	if graphFilePath != "" {
+		output, err := os.ReadFile(graphFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read graph file %s: %w", graphFilePath, err)
+		}
+		return output, nil
+	}
+
+	// Create a temporary file for cross-platform compatibility
+	tempFile, err := os.CreateTemp("", "nx_graph_*.json")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name()) // Ensure the file is cleaned up
+	}()
+
+	// Try nx command first (newer versions)
+	if _, cmdErr := runNx(".", "graph", fmt.Sprintf("--file=%s", tempFile.Name()), "--format=json"); cmdErr != nil {
+		// Fallback to older nx command
+		if _, cmdErr := runNx(".", "dep-graph", fmt.Sprintf("--file=%s", tempFile.Name()), "--format=json"); cmdErr != nil {
+			return nil, fmt.Errorf("failed to get project graph: %w", cmdErr)
+		}
 	}
 
-	// Return a copy to prevent external modifications
-	result := make([]string, len(changedFilesCache))
-	copy(result, changedFilesCache)
-	return result, nil
+	output, err := os.ReadFile(tempFile.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project graph file: %w", err)
+	}
+	return output, nil
 }
 
-// GetProjectGraph returns the NX project graph (cached)
+// GetProjectGraph returns just the node map of the project graph (cached),
+// kept for callers that only need project metadata, not dependency edges.
 func GetProjectGraph() (map[string]ProjectNode, error) {
-	var err error
-	projectGraphOnce.Do(func() {
-		// Create a temporary file for cross-platform compatibility
-		tempFile, tempErr := os.CreateTemp("", "nx_graph_*.json")
-		if tempErr != nil {
-			err = fmt.Errorf("failed to create temporary file: %w", tempErr)
-			return
-		}
-		defer func() {
-			tempFile.Close()
-			os.Remove(tempFile.Name()) // Ensure the file is cleaned up
-		}()
-
-		// Try nx command first (newer versions)
-		cmd := exec.Command("yarn", "nx", "graph", fmt.Sprintf("--file=%s", tempFile.Name()), "--format=json")
-		cmdErr := cmd.Run()
-
-		if cmdErr != nil {
-			// Fallback to older nx command
-			cmd = exec.Command("yarn", "nx", "dep-graph", fmt.Sprintf("--file=%s", tempFile.Name()), "--format=json")
-			cmdErr = cmd.Run()
-
-			if cmdErr != nil {
-				err = fmt.Errorf("failed to get project graph: %w", cmdErr)
-				return
-			}
-		}
+	graph, err := LoadProjectGraph()
+	if err != nil {
+		return nil, err
+	}
+	return graph.Nodes, nil
+}
 
-		// Read the generated file
-		output, readErr := os.ReadFile(tempFile.Name())
-		if readErr != nil {
-			err = fmt.Errorf("failed to read project graph file: %w", readErr)
-			return
-		}
+// GetChangedFiles returns files that changed between base and head commits,
+// cached in-memory per (base, head) pair so repeated calls within one
+// process (e.g. once per project) reuse the same `git diff` invocation
+// instead of each shelling out again.
+func GetChangedFiles(base, head string) ([]string, error) {
+	key := base + "\x00" + head
+
+	changedFilesMu.Lock()
+	if cached, ok := changedFilesCache[key]; ok {
+		changedFilesMu.Unlock()
+		result := make([]string, len(cached))
+		copy(result, cached)
+		return result, nil
+	}
+	changedFilesMu.Unlock()
+
+	// Build git diff command
+	var cmd *exec.Cmd
+	if base != "" && head != "" {
+		cmd = exec.Command("git", "diff", "--name-only", fmt.Sprintf("%s..%s", base, head))
+	} else if base != "" {
+		cmd = exec.Command("git", "diff", "--name-only", base)
+	} else {
+		// Default to uncommitted changes
+		cmd = exec.Command("git", "diff", "--name-only", "HEAD")
+	}
+
+	output, cmdErr := cmd.Output()
+	if cmdErr != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", cmdErr)
+	}
 
-		var graph ProjectGraph
-		if jsonErr := json.Unmarshal(output, &graph); jsonErr != nil {
-			err = fmt.Errorf("failed to parse project graph: %w", jsonErr)
-			return
+	outputStr := strings.TrimSpace(string(output))
+
+	changedFiles := []string{}
+	if outputStr != "" {
+		lines := strings.Split(outputStr, "\n")
+		for _, line := range lines {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				// Normalize path separators for cross-platform compatibility
+				changedFiles = append(changedFiles, strings.ReplaceAll(line, "\\", "/"))
+			}
 		}
+	}
 
-		projectGraphCache = graph.Nodes
-	})
+	changedFilesMu.Lock()
+	changedFilesCache[key] = changedFiles
+	changedFilesMu.Unlock()
 
-	return projectGraphCache, err
+	result := make([]string, len(changedFiles))
+	copy(result, changedFiles)
+	return result, nil
 }
 
-// GetChangedProjectsUsingGraph returns projects that have file changes between commits
-func GetChangedProjectsUsingGraph(base, head string) ([]string, error) {
+// AffectedFromGraph returns the projects affected by the changes between
+// base and head, combining `git diff --name-only` with a traversal of the
+// already-loaded project graph instead of a second `nx` invocation.
+func AffectedFromGraph(base, head string) ([]string, error) {
 	// Get changed files
 	changedFiles, err := GetChangedFiles(base, head)
 	if err != nil {
@@ -147,7 +229,7 @@ func GetChangedProjectsUsingGraph(base, head string) ([]string, error) {
 	}
 
 	// Get project graph
-	projectGraph, err := GetProjectGraph()
+	graph, err := LoadProjectGraph()
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +237,7 @@ func GetChangedProjectsUsingGraph(base, head string) ([]string, error) {
 	// Map changed files to projects
 	changedProjectsSet := make(map[string]bool)
 
-	for _, node := range projectGraph {
+	for _, node := range graph.Nodes {
 		projectRoot := strings.ReplaceAll(node.Data.Root, "\\", "/")
 
 		for _, file := range changedFiles {
@@ -167,6 +249,26 @@ func GetChangedProjectsUsingGraph(base, head string) ([]string, error) {
 		}
 	}
 
+	// Propagate affectedness over the dependency edges: any project that
+	// (transitively) depends on a directly-changed project is affected too,
+	// even though none of its own files changed.
+	dependents := invertDependencies(graph.Dependencies)
+	queue := make([]string, 0, len(changedProjectsSet))
+	for project := range changedProjectsSet {
+		queue = append(queue, project)
+	}
+	for len(queue) > 0 {
+		project := queue[0]
+		queue = queue[1:]
+
+		for _, dependent := range dependents[project] {
+			if !changedProjectsSet[dependent] {
+				changedProjectsSet[dependent] = true
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
 	// Convert set to slice
 	changedProjects := make([]string, 0, len(changedProjectsSet))
 	for project := range changedProjectsSet {
@@ -176,6 +278,26 @@ func GetChangedProjectsUsingGraph(base, head string) ([]string, error) {
 	return changedProjects, nil
 }
 
+// invertDependencies turns the project graph's source->edges dependency map
+// into a target->dependents map, so AffectedFromGraph can walk from a
+// changed project to everything that depends on it instead of the other
+// way around.
+func invertDependencies(deps map[string][]DependencyEdge) map[string][]string {
+	dependents := make(map[string][]string)
+	for source, edges := range deps {
+		for _, edge := range edges {
+			dependents[edge.Target] = append(dependents[edge.Target], source)
+		}
+	}
+	return dependents
+}
+
+// GetChangedProjectsUsingGraph is retained for existing callers; it now
+// simply calls AffectedFromGraph.
+func GetChangedProjectsUsingGraph(base, head string) ([]string, error) {
+	return AffectedFromGraph(base, head)
+}
+
 // ShouldSkipProject returns true if the project has no changes and should be skipped
 func ShouldSkipProject(projectName, base, head string, forceAll bool) (bool, error) {
 	// If forcing all projects, don't skip
@@ -189,7 +311,7 @@ func ShouldSkipProject(projectName, base, head string, forceAll bool) (bool, err
 	}
 
 	// Get changed projects
-	changedProjects, err := GetChangedProjectsUsingGraph(base, head)
+	changedProjects, err := AffectedFromGraph(base, head)
 	if err != nil {
 		// If we can't determine changes, err on the side of running the scan
 		return false, nil
@@ -0,0 +1,69 @@
+package nx
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Executor runs an external command and returns its combined stdout+stderr,
+// matching (*exec.Cmd).CombinedOutput. It's the seam every `nx`/package
+// manager shell-out in this package goes through, so tests can inject a
+// fake instead of actually invoking yarn/pnpm/npx.
+type Executor interface {
+	Run(name string, args ...string) ([]byte, error)
+}
+
+// execExecutor is the default Executor, shelling out via os/exec.
+type execExecutor struct{}
+
+func (execExecutor) Run(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+// executor is package-level so every call site (nx.go, changes.go) shares
+// the same seam; SetExecutor overrides it, e.g. for tests.
+var executor Executor = execExecutor{}
+
+// SetExecutor overrides the Executor used for `nx`/package manager
+// shell-outs. Passing nil restores the default os/exec-backed Executor.
+func SetExecutor(e Executor) {
+	if e == nil {
+		e = execExecutor{}
+	}
+	executor = e
+}
+
+// runNx shells out to `nx nxArgs...` via the package manager detected for
+// root, routing through wsl.exe when this binary is running on Windows but
+// the workspace lives on a WSL-mounted Linux filesystem.
+func runNx(root string, nxArgs ...string) ([]byte, error) {
+	pm := DetectPackageManager(root)
+	name, args := nxInvocation(pm, nxArgs...)
+	name, args = wrapForWSL(root, name, args)
+	return executor.Run(name, args...)
+}
+
+// wrapForWSL prepends `wsl.exe <name>` when this binary is running on
+// Windows and root resolves to a \\wsl$\ or \\wsl.localhost\ UNC path -
+// i.e. the Nx workspace lives inside a WSL distro's Linux filesystem, where
+// yarn/pnpm/npx aren't reachable as native Windows executables.
+func wrapForWSL(root, name string, args []string) (string, []string) {
+	if runtime.GOOS != "windows" {
+		return name, args
+	}
+	if !isWSLMountPath(root) {
+		return name, args
+	}
+	return "wsl.exe", append([]string{name}, args...)
+}
+
+func isWSLMountPath(root string) bool {
+	abs := root
+	if resolved, err := filepath.Abs(root); err == nil {
+		abs = resolved
+	}
+	lower := strings.ToLower(abs)
+	return strings.HasPrefix(lower, `\\wsl$\`) || strings.HasPrefix(lower, `\\wsl.localhost\`)
+}
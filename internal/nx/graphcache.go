@@ -0,0 +1,149 @@
+package nx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// graphCacheInputs lists, relative to the workspace root, the lockfiles
+// whose contents invalidate the on-disk project graph cache alongside
+// nx.json and every project.json.
+var graphCacheInputs = []string{
+	"nx.json",
+	"package.json",
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+}
+
+// graphCacheDirName is appended to the resolved cache directory so the
+// project graph cache doesn't collide with the scan-result cache that may
+// share the same --cache-dir.
+const graphCacheDirName = "graph"
+
+// graphCachePath returns the on-disk path for a graph cached under hash,
+// rooted at dir (the resolved --cache-dir, or $XDG_CACHE_HOME/fossa-nx if
+// dir is empty).
+func graphCachePath(dir, hash string) string {
+	if dir == "" {
+		dir = defaultCacheDir()
+	}
+	return filepath.Join(dir, graphCacheDirName, fmt.Sprintf("graph-%s.json", hash))
+}
+
+func defaultCacheDir() string {
+	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "fossa-nx")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".fossa-nx/cache"
+	}
+	return filepath.Join(home, ".cache", "fossa-nx")
+}
+
+// hashGraphInputs hashes nx.json, every project.json under the workspace,
+// and the root package.json/lockfiles, so the on-disk graph cache can be
+// invalidated whenever any of them changes.
+func hashGraphInputs() (string, error) {
+	h := sha256.New()
+
+	for _, name := range graphCacheInputs {
+		hashFileInto(h, name)
+	}
+
+	projectFiles, err := findProjectManifests(".")
+	if err != nil {
+		return "", fmt.Errorf("failed to enumerate project.json files: %w", err)
+	}
+	sort.Strings(projectFiles)
+	for _, path := range projectFiles {
+		hashFileInto(h, path)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFileInto(h interface{ Write([]byte) (int, error) }, path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(h, "%s:", path)
+	h.Write(data)
+}
+
+// findProjectManifests walks root looking for project.json files, skipping
+// directories that never contain Nx project sources.
+func findProjectManifests(root string) ([]string, error) {
+	var found []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case "node_modules", ".git", "dist", "build", ".nx":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == "project.json" {
+			found = append(found, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// loadCachedGraph returns the on-disk graph cached at dir, if its stored
+// hash still matches the current inputs.
+func loadCachedGraph(dir string) (*ProjectGraph, bool) {
+	hash, err := hashGraphInputs()
+	if err != nil {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(graphCachePath(dir, hash))
+	if err != nil {
+		return nil, false
+	}
+
+	var graph ProjectGraph
+	if err := json.Unmarshal(data, &graph); err != nil {
+		return nil, false
+	}
+
+	return &graph, true
+}
+
+// saveCachedGraph writes graph to the on-disk cache under the current
+// inputs' hash, so the next invocation (e.g. the next matrix job) can skip
+// re-running `nx graph` entirely.
+func saveCachedGraph(dir string, graph *ProjectGraph) error {
+	hash, err := hashGraphInputs()
+	if err != nil {
+		return err
+	}
+
+	path := graphCachePath(dir, hash)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create graph cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(graph)
+	if err != nil {
+		return fmt.Errorf("failed to serialize project graph: %w", err)
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,232 @@
+package nx
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/mapping"
+)
+
+// writeManifestFile replaces path's contents via temp-file-plus-rename
+// rather than an in-place O_TRUNC write. scanIsolated's workspace hardlinks
+// a project's files instead of copying them for speed, so an in-place write
+// here would write through the hardlink into the real in-tree manifest;
+// renaming over the directory entry instead leaves the original inode (and
+// therefore the source tree) untouched.
+func writeManifestFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-"+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file for %s: %w", path, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil {
+		return fmt.Errorf("failed to write temp file for %s: %w", path, writeErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close temp file for %s: %w", path, closeErr)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return fmt.Errorf("failed to set permissions on temp file for %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// ManifestHandler synthesizes whatever transient manifest FOSSA needs to
+// analyze a project's dependencies, and knows how to back up and restore
+// whatever it replaces. Each ecosystem (Node, pnpm, Go, Python, Maven) gets
+// its own implementation instead of RunAnalysis hard-coding package.json.
+type ManifestHandler interface {
+	// Ecosystem identifies the handler, matching mapping.TeamMapping.Ecosystem.
+	Ecosystem() string
+
+	// Detect reports whether projectRoot looks like it belongs to this
+	// ecosystem, used when no explicit Ecosystem mapping is configured.
+	Detect(projectRoot string) bool
+
+	// Prepare synthesizes the transient manifest for projectName rooted at
+	// projectRoot, and returns a cleanup func that restores prior state
+	// (backed-up manifest, removed symlinks, etc).
+	Prepare(projectName, projectRoot string) (cleanup func() error, err error)
+}
+
+// manifestHandlers is tried in order when no ecosystem is explicitly
+// configured; the first handler whose Detect matches wins.
+var manifestHandlers = []ManifestHandler{
+	&PnpmManifestHandler{},
+	&NodeManifestHandler{},
+	&GoManifestHandler{},
+	&PythonManifestHandler{},
+	&MavenManifestHandler{},
+}
+
+// SelectManifestHandler picks the handler for a project: it honors an
+// explicit mapping.TeamMapping.Ecosystem override first, then falls back to
+// detecting the ecosystem from the project's root directory contents.
+func SelectManifestHandler(projectName, projectRoot string) ManifestHandler {
+	if ecosystem := mapping.GetEcosystem(projectName); ecosystem != "" {
+		for _, handler := range manifestHandlers {
+			if handler.Ecosystem() == ecosystem {
+				return handler
+			}
+		}
+	}
+
+	for _, handler := range manifestHandlers {
+		if handler.Detect(projectRoot) {
+			return handler
+		}
+	}
+
+	// Default to Node/npm, the ecosystem this tool originally supported.
+	return &NodeManifestHandler{}
+}
+
+// NodeManifestHandler synthesizes a package.json via
+// CreateTemporaryPackageJson, which resolves dependencies from the project
+// graph and falls back to `nx show project --with-deps --json`.
+type NodeManifestHandler struct{}
+
+func (h *NodeManifestHandler) Ecosystem() string { return "node" }
+
+func (h *NodeManifestHandler) Detect(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, "package.json"))
+	return err == nil
+}
+
+func (h *NodeManifestHandler) Prepare(projectName, projectRoot string) (func() error, error) {
+	packageJsonPath := filepath.Join(projectRoot, "package.json")
+
+	var originalPackageJson []byte
+	packageJsonExisted := false
+	if data, err := os.ReadFile(packageJsonPath); err == nil {
+		originalPackageJson = data
+		packageJsonExisted = true
+	}
+
+	if _, err := CreateTemporaryPackageJson(projectName, projectRoot); err != nil {
+		return nil, err
+	}
+
+	nodeModulesCreated := false
+	nodeModulesPath := filepath.Join(projectRoot, "node_modules")
+	if monorepoRoot, err := getWorkspaceRoot(); err == nil {
+		monorepoNodeModules := filepath.Join(monorepoRoot, "node_modules")
+		if _, err := os.Stat(nodeModulesPath); os.IsNotExist(err) {
+			if err := os.Symlink(monorepoNodeModules, nodeModulesPath); err == nil {
+				nodeModulesCreated = true
+			}
+		}
+	}
+
+	return func() error {
+		if nodeModulesCreated {
+			os.Remove(nodeModulesPath)
+		}
+		if packageJsonExisted {
+			return writeManifestFile(packageJsonPath, originalPackageJson, 0644)
+		}
+		return os.Remove(packageJsonPath)
+	}, nil
+}
+
+func getWorkspaceRoot() (string, error) {
+	return os.Getwd()
+}
+
+// PnpmManifestHandler reuses the Node handler's package.json synthesis, but
+// is detected separately so pnpm-workspace.yaml projects aren't mistaken for
+// plain npm/yarn ones when ecosystem isn't explicitly configured.
+type PnpmManifestHandler struct {
+	NodeManifestHandler
+}
+
+func (h *PnpmManifestHandler) Ecosystem() string { return "pnpm" }
+
+func (h *PnpmManifestHandler) Detect(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, "pnpm-workspace.yaml"))
+	if err == nil {
+		return true
+	}
+	_, err = os.Stat(filepath.Join(projectRoot, "..", "pnpm-workspace.yaml"))
+	return err == nil
+}
+
+// GoManifestHandler backs up go.mod/go.sum and resolves dependencies via
+// `go list -m -deps -json` instead of synthesizing a package.json.
+type GoManifestHandler struct{}
+
+func (h *GoManifestHandler) Ecosystem() string { return "go" }
+
+func (h *GoManifestHandler) Detect(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, "go.mod"))
+	return err == nil
+}
+
+func (h *GoManifestHandler) Prepare(projectName, projectRoot string) (func() error, error) {
+	goModPath := filepath.Join(projectRoot, "go.mod")
+	goSumPath := filepath.Join(projectRoot, "go.sum")
+
+	originalGoMod, _ := os.ReadFile(goModPath)
+	originalGoSum, _ := os.ReadFile(goSumPath)
+
+	// go.mod/go.sum are already FOSSA-readable manifests; resolving deps is
+	// only needed to confirm the module graph is up to date before scanning.
+	cmd := exec.Command("go", "list", "-m", "-deps", "-json")
+	cmd.Dir = projectRoot
+	_ = cmd.Run() // best-effort: a failure here shouldn't block the scan
+
+	return func() error {
+		if originalGoMod != nil {
+			if err := writeManifestFile(goModPath, originalGoMod, 0644); err != nil {
+				return err
+			}
+		}
+		if originalGoSum != nil {
+			return writeManifestFile(goSumPath, originalGoSum, 0644)
+		}
+		return nil
+	}, nil
+}
+
+// PythonManifestHandler is a no-op beyond confirming a requirements.txt or
+// pyproject.toml exists; FOSSA reads those directly.
+type PythonManifestHandler struct{}
+
+func (h *PythonManifestHandler) Ecosystem() string { return "python" }
+
+func (h *PythonManifestHandler) Detect(projectRoot string) bool {
+	for _, name := range []string{"requirements.txt", "pyproject.toml"} {
+		if _, err := os.Stat(filepath.Join(projectRoot, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *PythonManifestHandler) Prepare(projectName, projectRoot string) (func() error, error) {
+	return func() error { return nil }, nil
+}
+
+// MavenManifestHandler is a no-op beyond confirming a pom.xml exists; FOSSA
+// reads it directly, same as the Python handler.
+type MavenManifestHandler struct{}
+
+func (h *MavenManifestHandler) Ecosystem() string { return "maven" }
+
+func (h *MavenManifestHandler) Detect(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, "pom.xml"))
+	return err == nil
+}
+
+func (h *MavenManifestHandler) Prepare(projectName, projectRoot string) (func() error, error) {
+	return func() error { return nil }, nil
+}
@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/log"
 )
 
 var (
@@ -42,10 +43,21 @@ func GetProjects(base, head string, getAllProjects bool) ([]string, error) {
 			return allProjectsCache, nil
 		}
 
-		// Get all projects
-		cmd := exec.Command("yarn", "nx", "show", "projects")
-		output, err := cmd.CombinedOutput()
+		// Prefer the batch-loaded project graph over a dedicated shell-out
+		if graph, err := LoadProjectGraph(); err == nil {
+			projects := make([]string, 0, len(graph.Nodes))
+			for name := range graph.Nodes {
+				projects = append(projects, name)
+			}
+			allProjectsCache = projects
+			allProjectsLoaded = true
+			return projects, nil
+		}
+
+		// Graph unavailable, fall back to a direct nx invocation
+		output, err := runNx(".", "show", "projects")
 		if err != nil {
+			log.With(log.Fields{"phase": "discover"}).WithError(err).Error("nx show projects failed")
 			return nil, fmt.Errorf("failed to run nx command: %w\nOutput: %s", err, output)
 		}
 
@@ -65,16 +77,24 @@ func GetProjects(base, head string, getAllProjects bool) ([]string, error) {
 
 		return projects, nil
 	} else {
-		// Get affected projects
+		// Prefer resolving affected projects from the batch-loaded graph
+		if base != "" && head != "" {
+			if projects, err := AffectedFromGraph(base, head); err == nil {
+				return projects, nil
+			}
+		}
+
+		// Graph unavailable (or no base/head given), fall back to `nx show
+		// projects --affected`
 		args := []string{"show", "projects", "--affected", "-t", "build"}
 
 		if base != "" && head != "" {
 			args = append(args, fmt.Sprintf("--base=%s", base), fmt.Sprintf("--head=%s", head))
 		}
 
-		cmd := exec.Command("yarn", append([]string{"nx"}, args...)...)
-		output, err := cmd.CombinedOutput()
+		output, err := runNx(".", args...)
 		if err != nil {
+			log.With(log.Fields{"phase": "discover", "base": base, "head": head}).WithError(err).Error("nx show projects --affected failed")
 			return nil, fmt.Errorf("failed to run nx command: %w\nOutput: %s", err, output)
 		}
 
@@ -122,11 +142,18 @@ func GetProjectRoot(projectName string) (string, error) {
 	return root, nil
 }
 
-// determineProjectRoot finds the project root using NX or fallback methods
+// determineProjectRoot finds the project root using the batch-loaded project
+// graph, falling back to a dedicated NX invocation or common directory
+// patterns if the graph isn't available.
 func determineProjectRoot(projectName string) (string, error) {
+	if graph, err := LoadProjectGraph(); err == nil {
+		if node, ok := graph.Nodes[projectName]; ok && node.Data.Root != "" {
+			return node.Data.Root, nil
+		}
+	}
+
 	// Use NX CLI to directly get project info (most reliable)
-	cmd := exec.Command("yarn", "nx", "show", "project", projectName, "--json")
-	output, err := cmd.CombinedOutput()
+	output, err := runNx(".", "show", "project", projectName, "--json")
 	if err == nil {
 		var projectInfo map[string]interface{}
 		if err := json.Unmarshal(output, &projectInfo); err == nil {
@@ -152,6 +179,7 @@ func determineProjectRoot(projectName string) (string, error) {
 		}
 	}
 
+	log.With(log.Fields{"project": projectName, "phase": "discover"}).Error("could not determine project root from nx or common directory patterns")
 	return "", fmt.Errorf("could not determine project root for %s", projectName)
 }
 
@@ -166,35 +194,43 @@ func CreateTemporaryPackageJson(projectName, projectRoot string) (string, error)
 		"dependencies": map[string]string{},
 	}
 
-	// Get project dependencies using nx show project
-	cmd := exec.Command("yarn", "nx", "show", "project", projectName, "--with-deps", "--json")
-	depOutput, err := cmd.CombinedOutput()
-
-	if err != nil {
-		// If getting dependencies fails, create a minimal package.json
-		jsonData, err := json.MarshalIndent(packageJSON, "", "  ")
-		if err != nil {
-			return "", fmt.Errorf("failed to serialize package.json: %w", err)
-		}
-
-		if err := os.WriteFile(packageJsonPath, jsonData, 0644); err != nil {
-			return "", fmt.Errorf("failed to write package.json: %w", err)
+	// Resolve this project's dependencies from the batch-loaded project
+	// graph instead of a per-project `yarn nx show project --with-deps` call.
+	if graph, err := LoadProjectGraph(); err == nil {
+		dependencies := packageJSON["dependencies"].(map[string]string)
+		for _, edge := range graph.Dependencies[projectName] {
+			dependencies[edge.Target] = "^1.0.0"
 		}
+	} else {
+		// Graph unavailable, fall back to a dedicated nx invocation
+		depOutput, cmdErr := runNx(".", "show", "project", projectName, "--with-deps", "--json")
+
+		if cmdErr != nil {
+			// If getting dependencies fails, create a minimal package.json
+			jsonData, err := json.MarshalIndent(packageJSON, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("failed to serialize package.json: %w", err)
+			}
 
-		return packageJsonPath, nil
-	}
+			if err := writeManifestFile(packageJsonPath, jsonData, 0644); err != nil {
+				return "", fmt.Errorf("failed to write package.json: %w", err)
+			}
 
-	// Parse dependency information
-	var depInfo map[string]interface{}
-	if err := json.Unmarshal(depOutput, &depInfo); err == nil {
-		// Add dependencies to package.json
-		dependencies := packageJSON["dependencies"].(map[string]string)
+			return packageJsonPath, nil
+		}
 
-		// Add all projects that this project depends on
-		if deps, ok := depInfo["dependencies"].([]interface{}); ok {
-			for _, dep := range deps {
-				if depName, ok := dep.(string); ok {
-					dependencies[depName] = "^1.0.0"
+		// Parse dependency information
+		var depInfo map[string]interface{}
+		if err := json.Unmarshal(depOutput, &depInfo); err == nil {
+			// Add dependencies to package.json
+			dependencies := packageJSON["dependencies"].(map[string]string)
+
+			// Add all projects that this project depends on
+			if deps, ok := depInfo["dependencies"].([]interface{}); ok {
+				for _, dep := range deps {
+					if depName, ok := dep.(string); ok {
+						dependencies[depName] = "^1.0.0"
+					}
 				}
 			}
 		}
@@ -228,7 +264,7 @@ func CreateTemporaryPackageJson(projectName, projectRoot string) (string, error)
 		return "", fmt.Errorf("failed to serialize package.json: %w", err)
 	}
 
-	if err := os.WriteFile(packageJsonPath, jsonData, 0644); err != nil {
+	if err := writeManifestFile(packageJsonPath, jsonData, 0644); err != nil {
 		return "", fmt.Errorf("failed to write package.json: %w", err)
 	}
 
@@ -0,0 +1,69 @@
+package nx
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PackageManager identifies which JS package manager a workspace uses, so
+// the right binary (and `nx` invocation style) gets shelled out to instead
+// of hardcoding `yarn`.
+type PackageManager string
+
+const (
+	Yarn PackageManager = "yarn"
+	Pnpm PackageManager = "pnpm"
+	Npm  PackageManager = "npm"
+)
+
+// DetectPackageManager determines root's package manager, first from the
+// root package.json's "packageManager" field (e.g. "pnpm@8.6.0", per
+// Corepack convention), then by lockfile presence, defaulting to Yarn to
+// match this package's historical `yarn nx ...` invocations.
+func DetectPackageManager(root string) PackageManager {
+	if data, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		var pkg struct {
+			PackageManager string `json:"packageManager"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.PackageManager != "" {
+			name, _, _ := strings.Cut(pkg.PackageManager, "@")
+			switch PackageManager(name) {
+			case Pnpm, Npm, Yarn:
+				return PackageManager(name)
+			}
+		}
+	}
+
+	switch {
+	case fileExists(filepath.Join(root, "pnpm-lock.yaml")):
+		return Pnpm
+	case fileExists(filepath.Join(root, "package-lock.json")):
+		return Npm
+	case fileExists(filepath.Join(root, "yarn.lock")):
+		return Yarn
+	default:
+		return Yarn
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// nxInvocation returns the binary and arguments to run `nx` with nxArgs
+// under pm: `yarn nx ...`, `pnpm nx ...`, or `npx nx ...` (npm has no
+// equivalent to `yarn nx`/`pnpm nx`, so npx is used to resolve the locally
+// installed `nx` binary).
+func nxInvocation(pm PackageManager, nxArgs ...string) (string, []string) {
+	switch pm {
+	case Pnpm:
+		return "pnpm", append([]string{"nx"}, nxArgs...)
+	case Npm:
+		return "npx", append([]string{"nx"}, nxArgs...)
+	default:
+		return "yarn", append([]string{"nx"}, nxArgs...)
+	}
+}
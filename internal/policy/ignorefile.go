@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// IgnoreEntry suppresses a known-accepted CVE until it expires.
+type IgnoreEntry struct {
+	CVE     string    `yaml:"cve"`
+	Expires time.Time `yaml:"expires"`
+	Reason  string    `yaml:"reason"`
+}
+
+// LoadIgnoreFile reads a YAML list of IgnoreEntry from path.
+func LoadIgnoreFile(path string) ([]IgnoreEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore file %s: %w", path, err)
+	}
+
+	var entries []IgnoreEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse ignore file %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// ApplyIgnores drops any issue matching an unexpired entry from every
+// result's Issues slice, in place.
+func ApplyIgnores(results []models.Result, entries []IgnoreEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	now := time.Now()
+	suppressed := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry.Expires.IsZero() || entry.Expires.After(now) {
+			suppressed[entry.CVE] = true
+		}
+	}
+
+	for i := range results {
+		filtered := results[i].Issues[:0]
+		for _, issue := range results[i].Issues {
+			if suppressed[issue.CVE] {
+				continue
+			}
+			filtered = append(filtered, issue)
+		}
+		results[i].Issues = filtered
+	}
+}
@@ -0,0 +1,77 @@
+// Package policy evaluates severity-gating rules against scan results, so a
+// CI run can fail only on truly actionable vulnerabilities instead of every
+// low-severity transitive dependency.
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// severityRank orders FOSSA severities for threshold comparisons, so
+// "--fail-on high" also fails on a critical-severity issue.
+var severityRank = map[string]int{
+	"low":      1,
+	"medium":   2,
+	"moderate": 2,
+	"high":     3,
+	"critical": 4,
+}
+
+// Evaluate reports whether any issue across results matches failOn, a
+// comma-separated list of conditions: a severity threshold ("high",
+// "critical", ...), an EPSS threshold ("epss>=0.5"), or "kev". An empty
+// failOn never matches.
+func Evaluate(results []models.Result, failOn string) (bool, error) {
+	conditions := strings.Split(failOn, ",")
+
+	for _, result := range results {
+		for _, issue := range result.Issues {
+			for _, cond := range conditions {
+				cond = strings.TrimSpace(cond)
+				if cond == "" {
+					continue
+				}
+
+				matched, err := matches(issue, cond)
+				if err != nil {
+					return false, err
+				}
+				if matched {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func matches(issue models.VulnerabilityIssue, cond string) (bool, error) {
+	if cond == "kev" {
+		return issue.KEV, nil
+	}
+
+	if rest, ok := strings.CutPrefix(cond, "epss>="); ok {
+		threshold, err := strconv.ParseFloat(rest, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid --fail-on condition %q: %w", cond, err)
+		}
+		return issue.EPSSScore >= threshold, nil
+	}
+
+	threshold, ok := severityRank[strings.ToLower(cond)]
+	if !ok {
+		return false, fmt.Errorf("unknown --fail-on condition %q", cond)
+	}
+
+	rank, ok := severityRank[strings.ToLower(issue.Severity)]
+	if !ok {
+		return false, nil
+	}
+
+	return rank >= threshold, nil
+}
@@ -0,0 +1,178 @@
+// Package remediation computes and publishes dependency bumps for
+// FOSSA-reported vulnerabilities that have a known fixed version.
+package remediation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/nx"
+)
+
+// Fix describes a single dependency bump required to resolve one vulnerability.
+type Fix struct {
+	Project      string
+	ManifestPath string
+	PackageName  string
+	CurrentRange string
+	FixedVersion string
+	CVE          string
+	Branch       string
+	Issue        models.VulnerabilityIssue
+}
+
+// Planner finds the package.json that pins a vulnerable package (the
+// project's own, then the workspace root's), and resolves the minimum
+// semver-satisfying fixed version for it.
+type Planner struct{}
+
+// NewPlanner returns a ready-to-use Planner.
+func NewPlanner() *Planner {
+	return &Planner{}
+}
+
+// Plan computes the Fix required to remediate a single vulnerability in a
+// project, or an error if the issue carries no fixed version or the
+// package cannot be located in the project's manifest.
+func (p *Planner) Plan(project string, issue models.VulnerabilityIssue) (*Fix, error) {
+	if issue.FixedIn == "" {
+		return nil, fmt.Errorf("vulnerability %s has no fixed version to remediate to", issue.Name)
+	}
+
+	manifestPath, currentRange, err := p.locateDependency(project, issue.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate %s in %s's manifest: %w", issue.Name, project, err)
+	}
+
+	fixedVersion, err := resolveFixedVersion(currentRange, issue.FixedIn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve fixed version for %s: %w", issue.Name, err)
+	}
+
+	return &Fix{
+		Project:      project,
+		ManifestPath: manifestPath,
+		PackageName:  issue.Name,
+		CurrentRange: currentRange,
+		FixedVersion: fixedVersion,
+		CVE:          issue.CVE,
+		Branch:       branchName(project, issue.CVE),
+		Issue:        issue,
+	}, nil
+}
+
+// locateDependency looks for pkgName in the project's own package.json
+// (found via nx.GetProjectRoot), then in the workspace root's package.json,
+// and returns the first manifest that pins it with its current version
+// range. Other workspace packages and transitive dependencies are not
+// searched.
+func (p *Planner) locateDependency(project, pkgName string) (manifestPath, currentRange string, err error) {
+	root, err := nx.GetProjectRoot(project)
+	if err != nil {
+		return "", "", err
+	}
+
+	candidates := []string{filepath.Join(root, "package.json")}
+	if wsRoot, walkErr := filepath.Abs("."); walkErr == nil {
+		candidates = append(candidates, filepath.Join(wsRoot, "package.json"))
+	}
+
+	for _, candidate := range candidates {
+		data, readErr := os.ReadFile(candidate)
+		if readErr != nil {
+			continue
+		}
+		if rng, ok := findDependencyRange(string(data), pkgName); ok {
+			return candidate, rng, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("package %s not found in any workspace package.json", pkgName)
+}
+
+var dependencyLineRe = regexp.MustCompile(`"([a-zA-Z0-9@/._-]+)"\s*:\s*"([^"]*)"`)
+
+// findDependencyRange scans a package.json's raw text for pkgName's version
+// range, without going through encoding/json so that callers can later patch
+// the same line in place and preserve key order and indentation.
+func findDependencyRange(raw, pkgName string) (string, bool) {
+	for _, match := range dependencyLineRe.FindAllStringSubmatch(raw, -1) {
+		if match[1] == pkgName {
+			return match[2], true
+		}
+	}
+	return "", false
+}
+
+// resolveFixedVersion takes the dependency's current semver range (e.g.
+// `^1.2.0`, `~1.2.0`) and FOSSA's reported fixed-in version, and returns the
+// new range to pin: the fixed version with whatever prefix operator the
+// manifest already used.
+func resolveFixedVersion(currentRange, fixedIn string) (string, error) {
+	prefix := ""
+	switch {
+	case strings.HasPrefix(currentRange, "^"):
+		prefix = "^"
+	case strings.HasPrefix(currentRange, "~"):
+		prefix = "~"
+	}
+
+	fixedIn = strings.TrimPrefix(strings.TrimPrefix(fixedIn, "^"), "~")
+	if !isSemver(fixedIn) {
+		return "", fmt.Errorf("fixed version %q is not a valid semver", fixedIn)
+	}
+
+	return prefix + fixedIn, nil
+}
+
+var semverRe = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+func isSemver(v string) bool {
+	return semverRe.MatchString(v)
+}
+
+// ApplyFix rewrites the manifest in place, replacing the pinned version for
+// fix.PackageName while leaving every other key, ordering, and the file's
+// indentation untouched.
+func (p *Planner) ApplyFix(fix *Fix) error {
+	data, err := os.ReadFile(fix.ManifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to read manifest %s: %w", fix.ManifestPath, err)
+	}
+
+	replacement := regexp.MustCompile(`("` + regexp.QuoteMeta(fix.PackageName) + `"\s*:\s*)"[^"]*"`)
+	updated := replacement.ReplaceAll(data, []byte(`${1}"`+fix.FixedVersion+`"`))
+
+	if string(updated) == string(data) {
+		return fmt.Errorf("no occurrence of %s was rewritten in %s", fix.PackageName, fix.ManifestPath)
+	}
+
+	info, err := os.Stat(fix.ManifestPath)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fix.ManifestPath, updated, info.Mode())
+}
+
+// branchName mirrors the convention used for remediation branches:
+// fossa-nx/fix-<cve>-<project>.
+func branchName(project, cve string) string {
+	slug := strings.ToLower(cve)
+	if slug == "" {
+		slug = "unknown-cve"
+	}
+	slug = strings.ReplaceAll(slug, " ", "-")
+	return fmt.Sprintf("fossa-nx/fix-%s-%s", slug, project)
+}
+
+// batchKey groups fixes belonging to the same scan into a single branch name
+// when --batch-remediation is set.
+func batchKey(n int) string {
+	return "fossa-nx/fix-batch-" + strconv.Itoa(n)
+}
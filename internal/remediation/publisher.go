@@ -0,0 +1,300 @@
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/v71/github"
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	ghnotify "github.com/kamalesh-seervi/fossa-nx/internal/notify/github"
+	"golang.org/x/oauth2"
+)
+
+// Publisher commits planned Fixes to a branch and opens (or updates) a pull
+// request for them, using the same GitHub configuration as the issue
+// notifier.
+type Publisher struct {
+	config models.GitHubConfig
+}
+
+// NewPublisher returns a Publisher bound to the given GitHub configuration.
+func NewPublisher(config models.GitHubConfig) *Publisher {
+	return &Publisher{config: config}
+}
+
+// Publish commits and opens PRs for the given fixes. When batch is true, all
+// fixes are committed on a single branch and published as one PR; otherwise
+// each fix gets its own branch and PR.
+func (pub *Publisher) Publish(ctx context.Context, fixes []*Fix, batch bool) error {
+	if !pub.config.Enabled || len(fixes) == 0 {
+		return nil
+	}
+
+	client := pub.newClient(ctx)
+
+	if batch {
+		return pub.publishBatch(ctx, client, fixes)
+	}
+
+	for _, fix := range fixes {
+		if err := pub.publishOne(ctx, client, fix); err != nil {
+			log.Printf("failed to publish remediation PR for %s (%s): %v", fix.PackageName, fix.Project, err)
+		}
+	}
+
+	return nil
+}
+
+func (pub *Publisher) newClient(ctx context.Context) *github.Client {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: pub.config.Token})
+	tc := oauth2.NewClient(ctx, ts)
+	client := github.NewClient(tc)
+
+	if pub.config.ApiUrl != "" {
+		if baseURL, err := url.Parse(pub.config.ApiUrl + "/"); err == nil {
+			client.BaseURL = baseURL
+			client.UploadURL = baseURL
+		}
+	}
+
+	return client
+}
+
+func (pub *Publisher) publishOne(ctx context.Context, client *github.Client, fix *Fix) error {
+	if err := commitFix(fix.Branch, pub.baseBranch(), []*Fix{fix}); err != nil {
+		pub.reportFailure(ctx, client, fix, err)
+		return err
+	}
+
+	title := fmt.Sprintf("fix(%s): bump %s to %s", fix.Project, fix.PackageName, fix.FixedVersion)
+	body := prBody([]*Fix{fix}, pub.trackingRefs(ctx, client, []*Fix{fix}))
+
+	if err := pub.openOrUpdatePR(ctx, client, fix.Branch, title, body); err != nil {
+		pub.reportFailure(ctx, client, fix, err)
+		return err
+	}
+
+	return nil
+}
+
+func (pub *Publisher) publishBatch(ctx context.Context, client *github.Client, fixes []*Fix) error {
+	branch := batchKey(len(fixes))
+	if err := commitFix(branch, pub.baseBranch(), fixes); err != nil {
+		pub.reportFailures(ctx, client, fixes, err)
+		return err
+	}
+
+	title := fmt.Sprintf("fix: remediate %d FOSSA-detected vulnerabilities", len(fixes))
+	body := prBody(fixes, pub.trackingRefs(ctx, client, fixes))
+
+	if err := pub.openOrUpdatePR(ctx, client, branch, title, body); err != nil {
+		pub.reportFailures(ctx, client, fixes, err)
+		return err
+	}
+
+	return nil
+}
+
+// baseBranch resolves the branch remediation PRs target: the configured
+// DefaultBranch if set, otherwise origin's HEAD (as set by `git clone`),
+// falling back to "main" if that can't be determined either.
+func (pub *Publisher) baseBranch() string {
+	if pub.config.DefaultBranch != "" {
+		return pub.config.DefaultBranch
+	}
+
+	if out, err := exec.Command("git", "symbolic-ref", "refs/remotes/origin/HEAD").Output(); err == nil {
+		ref := strings.TrimSpace(string(out))
+		if _, name, ok := strings.Cut(ref, "refs/remotes/origin/"); ok {
+			return name
+		}
+	}
+
+	return "main"
+}
+
+// trackingRefs resolves the open FOSSA issue number (if any) for each fix,
+// so the PR body can link back to the issue CreateIssues filed for it.
+func (pub *Publisher) trackingRefs(ctx context.Context, client *github.Client, fixes []*Fix) map[string]int {
+	refs := make(map[string]int, len(fixes))
+
+	for _, fix := range fixes {
+		if number, ok, err := ghnotify.FindTrackingIssue(ctx, client, pub.config, fix.Project, fix.Issue); err == nil && ok {
+			refs[fixKey(fix)] = number
+		}
+	}
+
+	return refs
+}
+
+// reportFailure surfaces a remediation failure (lock conflict, yarn error,
+// ...) as a comment on fix's tracking issue rather than only the scan log,
+// so a reviewer watching the issue actually sees it.
+func (pub *Publisher) reportFailure(ctx context.Context, client *github.Client, fix *Fix, cause error) {
+	message := fmt.Sprintf("Automatic remediation failed for %s (target version %s): %v", fix.PackageName, fix.FixedVersion, cause)
+	if err := ghnotify.CommentOnTrackingIssue(ctx, client, pub.config, fix.Project, fix.Issue, message); err != nil {
+		log.Printf("failed to comment on tracking issue for %s (%s): %v", fix.PackageName, fix.Project, err)
+	}
+}
+
+func (pub *Publisher) reportFailures(ctx context.Context, client *github.Client, fixes []*Fix, cause error) {
+	for _, fix := range fixes {
+		pub.reportFailure(ctx, client, fix, cause)
+	}
+}
+
+func fixKey(fix *Fix) string {
+	return fix.Project + "|" + fix.PackageName
+}
+
+func (pub *Publisher) openOrUpdatePR(ctx context.Context, client *github.Client, branch, title, body string) error {
+	base := pub.baseBranch()
+
+	existing, _, err := client.PullRequests.List(ctx, pub.config.Organization, pub.config.Repository, &github.PullRequestListOptions{
+		Head:  fmt.Sprintf("%s:%s", pub.config.Organization, branch),
+		State: "open",
+	})
+	if err == nil {
+		for _, pr := range existing {
+			if pr.GetHead().GetRef() == branch {
+				_, _, updateErr := client.PullRequests.Edit(ctx, pub.config.Organization, pub.config.Repository, pr.GetNumber(), &github.PullRequest{
+					Title: &title,
+					Body:  &body,
+				})
+				return updateErr
+			}
+		}
+	}
+
+	_, _, err = client.PullRequests.Create(ctx, pub.config.Organization, pub.config.Repository, &github.NewPullRequest{
+		Title: &title,
+		Head:  &branch,
+		Base:  &base,
+		Body:  &body,
+	})
+
+	return err
+}
+
+// commitFix applies every fix's manifest edit and commits+pushes the result
+// on branch (based off base), entirely inside a throwaway `git worktree`
+// checkout rather than the caller's own working tree - so remediation never
+// switches the branch out from under whatever the live checkout is doing
+// mid-scan, and never force-pushes anything but its own dedicated branch.
+func commitFix(branch, base string, fixes []*Fix) error {
+	worktreeDir, err := os.MkdirTemp("", "fossa-nx-remediation-*")
+	if err != nil {
+		return fmt.Errorf("failed to create remediation worktree dir: %w", err)
+	}
+	defer os.RemoveAll(worktreeDir)
+
+	if err := run("git", "worktree", "add", "-B", branch, worktreeDir, worktreeStartPoint(base)); err != nil {
+		return fmt.Errorf("failed to create worktree for %s: %w", branch, err)
+	}
+	defer run("git", "worktree", "remove", "--force", worktreeDir)
+
+	planner := NewPlanner()
+	for _, fix := range fixes {
+		relManifestPath, err := workspaceRelative(fix.ManifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s relative to the workspace root: %w", fix.ManifestPath, err)
+		}
+
+		fixInWorktree := *fix
+		fixInWorktree.ManifestPath = filepath.Join(worktreeDir, relManifestPath)
+		if err := planner.ApplyFix(&fixInWorktree); err != nil {
+			return err
+		}
+		if err := runIn(worktreeDir, "git", "add", relManifestPath); err != nil {
+			return err
+		}
+	}
+
+	message := commitMessage(fixes)
+	if err := runIn(worktreeDir, "git", "commit", "-m", message); err != nil {
+		return fmt.Errorf("failed to commit remediation changes: %w", err)
+	}
+
+	return runIn(worktreeDir, "git", "push", "-f", "origin", branch)
+}
+
+// workspaceRelative converts path to a path relative to the workspace root
+// (the live checkout's cwd), which is what planner.locateDependency's
+// root-pinned candidate (built from filepath.Abs(".")) returns as an
+// absolute path - commitFix needs it relative so it can rejoin it under the
+// remediation worktree instead of the caller's own working tree.
+func workspaceRelative(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return path, nil
+	}
+
+	wsRoot, err := filepath.Abs(".")
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Rel(wsRoot, path)
+}
+
+// worktreeStartPoint prefers a local ref for base (the common case when the
+// live checkout already has it), falling back to its remote-tracking ref so
+// a shallow or single-branch clone still works.
+func worktreeStartPoint(base string) string {
+	if err := exec.Command("git", "rev-parse", "--verify", "refs/heads/"+base).Run(); err == nil {
+		return base
+	}
+	return "origin/" + base
+}
+
+func commitMessage(fixes []*Fix) string {
+	if len(fixes) == 1 {
+		f := fixes[0]
+		return fmt.Sprintf("fix(%s): bump %s to %s (%s)", f.Project, f.PackageName, f.FixedVersion, f.CVE)
+	}
+	return fmt.Sprintf("fix: bump %d vulnerable dependencies", len(fixes))
+}
+
+func prBody(fixes []*Fix, refs map[string]int) string {
+	var b strings.Builder
+	b.WriteString("## FOSSA Remediation\n\n")
+	b.WriteString("This PR bumps the following dependencies to their FOSSA-reported fixed version:\n\n")
+	b.WriteString("| Project | Package | Severity | CVE | Fixed version | Tracking issue |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+
+	for _, f := range fixes {
+		ref := "-"
+		if number, ok := refs[fixKey(f)]; ok {
+			ref = fmt.Sprintf("Closes #%d", number)
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+			f.Project, f.PackageName, f.Issue.Severity, f.CVE, f.FixedVersion, ref))
+	}
+
+	b.WriteString("\n---\n*Opened automatically by fossa-nx remediation*\n")
+	return b.String()
+}
+
+func run(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s %s: %w\n%s", name, strings.Join(args, " "), err, output)
+	}
+	return nil
+}
+
+// runIn is run, but executed with dir as the working directory - used to
+// operate inside a remediation worktree instead of the caller's own.
+func runIn(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s %s: %w\n%s", name, strings.Join(args, " "), err, output)
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+// Package report renders a scan run's results through a pluggable Reporter,
+// so the same project-completion and final-summary data can be printed as
+// human-readable text or emitted as schema-versioned JSON/NDJSON events for
+// Nx Cloud dashboards, GitHub Actions job summaries, and log-aggregation
+// pipelines that can't parse free-form text.
+package report
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// Schema is the current schema version stamped on every emitted event.
+const Schema = "v1"
+
+//go:embed schema.json
+var schemaJSON []byte
+
+// SchemaJSON returns the embedded JSON Schema describing every event this
+// package can emit, for the --output-schema flag to print.
+func SchemaJSON() []byte {
+	return schemaJSON
+}
+
+// Format selects how a Reporter renders results.
+type Format string
+
+const (
+	FormatText   Format = "text"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+)
+
+// IsValidFormat reports whether format is one supported by New.
+func IsValidFormat(format Format) bool {
+	switch format {
+	case FormatText, FormatJSON, FormatNDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProjectResultEvent is emitted once per completed project.
+type ProjectResultEvent struct {
+	Type            string                      `json:"type"`
+	Schema          string                      `json:"schema"`
+	Project         string                      `json:"project"`
+	Success         bool                        `json:"success"`
+	Error           string                      `json:"error,omitempty"`
+	DurationMs      int64                       `json:"duration_ms"`
+	FossaLink       string                      `json:"fossa_link,omitempty"`
+	DependencyCount int                         `json:"dependency_count,omitempty"`
+	Issues          []models.VulnerabilityIssue `json:"issues"`
+}
+
+// RunSummaryEvent is emitted once, after every project has completed,
+// mirroring the fields of models.Stats.
+type RunSummaryEvent struct {
+	Type            string `json:"type"`
+	Schema          string `json:"schema"`
+	TotalProjects   int32  `json:"total_projects"`
+	Successful      int32  `json:"successful"`
+	Failed          int32  `json:"failed"`
+	Vulnerabilities int32  `json:"vulnerabilities"`
+	AvgDurationMs   int64  `json:"avg_duration_ms"`
+	MinDurationMs   int64  `json:"min_duration_ms"`
+	MaxDurationMs   int64  `json:"max_duration_ms"`
+}
+
+// Reporter is notified as each project finishes and once when the whole run
+// ends. Close flushes any buffered output and must be called exactly once.
+type Reporter interface {
+	ProjectResult(result models.Result)
+	RunSummary(stats *models.Stats)
+	Close() error
+}
+
+// New returns a Reporter that writes to w. verbose only affects FormatText,
+// matching processProjectsOptimized's prior log.Printf behavior.
+func New(format Format, w io.Writer, verbose bool) (Reporter, error) {
+	switch format {
+	case "", FormatText:
+		return &textReporter{w: w, verbose: verbose}, nil
+	case FormatJSON:
+		return &jsonReporter{w: w, array: true}, nil
+	case FormatNDJSON:
+		return &jsonReporter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown --output format %q (want text, json, or ndjson)", format)
+	}
+}
+
+// textReporter reproduces the CLI's historical human-readable log lines.
+type textReporter struct {
+	w       io.Writer
+	verbose bool
+}
+
+func (r *textReporter) ProjectResult(result models.Result) {
+	success := result.Error == nil
+	if !r.verbose && success {
+		return
+	}
+
+	if success {
+		fmt.Fprintf(r.w, "✓ %s (%.2fs)\n", result.Project, result.Duration.Seconds())
+		if len(result.Issues) > 0 {
+			fmt.Fprintf(r.w, "  Found %d vulnerabilities\n", len(result.Issues))
+		}
+	} else {
+		fmt.Fprintf(r.w, "✗ %s: %v (%.2fs)\n", result.Project, result.Error, result.Duration.Seconds())
+	}
+}
+
+func (r *textReporter) RunSummary(stats *models.Stats) {
+	successful := atomic.LoadInt32(&stats.Successful)
+	failed := atomic.LoadInt32(&stats.Failed)
+	total := atomic.LoadInt32(&stats.TotalProjects)
+	vulnCount := atomic.LoadInt32(&stats.Vulnerabilities)
+	totalDuration := time.Duration(atomic.LoadInt64(&stats.TotalDuration))
+	minDuration := time.Duration(atomic.LoadInt64(&stats.MinDuration))
+	maxDuration := time.Duration(atomic.LoadInt64(&stats.MaxDuration))
+
+	avgDuration := time.Duration(0)
+	if successful+failed > 0 {
+		avgDuration = totalDuration / time.Duration(successful+failed)
+	}
+
+	fmt.Fprintf(r.w, "FOSSA Analysis Stats:\n")
+	fmt.Fprintf(r.w, "  Total Projects: %d\n", total)
+	fmt.Fprintf(r.w, "  Successful: %d\n", successful)
+	fmt.Fprintf(r.w, "  Failed: %d\n", failed)
+	fmt.Fprintf(r.w, "  Vulnerabilities Found: %d\n", vulnCount)
+
+	if avgDuration.Seconds() > 60.0 {
+		fmt.Fprintf(r.w, "  Average Duration: %.2f minutes\n", avgDuration.Minutes())
+	} else {
+		fmt.Fprintf(r.w, "  Average Duration: %.2f seconds\n", avgDuration.Seconds())
+	}
+
+	if successful+failed > 0 {
+		if minDuration.Seconds() > 60.0 {
+			fmt.Fprintf(r.w, "  Min Duration: %.2f minutes\n", minDuration.Minutes())
+		} else {
+			fmt.Fprintf(r.w, "  Min Duration: %.2f seconds\n", minDuration.Seconds())
+		}
+
+		if maxDuration.Seconds() > 60.0 {
+			fmt.Fprintf(r.w, "  Max Duration: %.2f minutes\n", maxDuration.Minutes())
+		} else {
+			fmt.Fprintf(r.w, "  Max Duration: %.2f seconds\n", maxDuration.Seconds())
+		}
+	}
+}
+
+func (r *textReporter) Close() error {
+	return nil
+}
+
+// jsonReporter emits schema-versioned events as either one JSON array
+// (FormatJSON) or one object per line (FormatNDJSON/array=false).
+type jsonReporter struct {
+	w      io.Writer
+	array  bool
+	events []interface{}
+}
+
+func (r *jsonReporter) ProjectResult(result models.Result) {
+	r.emit(ProjectEvent(result))
+}
+
+func (r *jsonReporter) RunSummary(stats *models.Stats) {
+	r.emit(SummaryEvent(stats))
+}
+
+// SummaryEvent snapshots stats into a RunSummaryEvent, the same computation
+// jsonReporter uses for --output=json/ndjson, so other consumers (e.g. the
+// notify package's webhook payload) can reuse it instead of re-deriving the
+// average/min/max duration math against the atomic fields themselves.
+func SummaryEvent(stats *models.Stats) RunSummaryEvent {
+	successful := atomic.LoadInt32(&stats.Successful)
+	failed := atomic.LoadInt32(&stats.Failed)
+	totalDuration := time.Duration(atomic.LoadInt64(&stats.TotalDuration))
+
+	avgDurationMs := int64(0)
+	if successful+failed > 0 {
+		avgDurationMs = (totalDuration / time.Duration(successful+failed)).Milliseconds()
+	}
+
+	return RunSummaryEvent{
+		Type:            "run_summary",
+		Schema:          Schema,
+		TotalProjects:   atomic.LoadInt32(&stats.TotalProjects),
+		Successful:      successful,
+		Failed:          failed,
+		Vulnerabilities: atomic.LoadInt32(&stats.Vulnerabilities),
+		AvgDurationMs:   avgDurationMs,
+		MinDurationMs:   time.Duration(atomic.LoadInt64(&stats.MinDuration)).Milliseconds(),
+		MaxDurationMs:   time.Duration(atomic.LoadInt64(&stats.MaxDuration)).Milliseconds(),
+	}
+}
+
+// SummaryFromResults computes a RunSummaryEvent directly from a result
+// slice rather than a live Stats, for callers like `merge-shards` that
+// only have the already-finished results of potentially several shards to
+// work from.
+func SummaryFromResults(results []models.Result) RunSummaryEvent {
+	event := RunSummaryEvent{
+		Type:          "run_summary",
+		Schema:        Schema,
+		TotalProjects: int32(len(results)),
+	}
+
+	var totalDuration time.Duration
+	for _, result := range results {
+		if result.Error != nil {
+			event.Failed++
+			continue
+		}
+
+		event.Successful++
+		event.Vulnerabilities += int32(len(result.Issues))
+		totalDuration += result.Duration
+
+		durationMs := result.Duration.Milliseconds()
+		if event.MinDurationMs == 0 || durationMs < event.MinDurationMs {
+			event.MinDurationMs = durationMs
+		}
+		if durationMs > event.MaxDurationMs {
+			event.MaxDurationMs = durationMs
+		}
+	}
+
+	if event.Successful+event.Failed > 0 {
+		event.AvgDurationMs = (totalDuration / time.Duration(event.Successful+event.Failed)).Milliseconds()
+	}
+
+	return event
+}
+
+// ProjectEvent builds a ProjectResultEvent from result, shared by the JSON
+// reporter and the notify package's webhook payload.
+func ProjectEvent(result models.Result) ProjectResultEvent {
+	event := ProjectResultEvent{
+		Type:            "project_result",
+		Schema:          Schema,
+		Project:         result.Project,
+		Success:         result.Error == nil,
+		DurationMs:      result.Duration.Milliseconds(),
+		FossaLink:       result.FossaLink,
+		DependencyCount: result.DependencyCount,
+		Issues:          result.Issues,
+	}
+	if result.Error != nil {
+		event.Error = result.Error.Error()
+	}
+	return event
+}
+
+func (r *jsonReporter) emit(event interface{}) {
+	if r.array {
+		r.events = append(r.events, event)
+		return
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error encoding %T event: %v", event, err)
+		return
+	}
+	r.w.Write(append(data, '\n'))
+}
+
+func (r *jsonReporter) Close() error {
+	if !r.array {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.events, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output events: %w", err)
+	}
+
+	if _, err := r.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write output events: %w", err)
+	}
+	return nil
+}
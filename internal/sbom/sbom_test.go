@@ -0,0 +1,129 @@
+package sbom
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata/")
+
+// testComponents is the fixed component set every golden-file test renders,
+// covering license present/absent so omitempty behavior is exercised; both
+// components carry a purl.
+var testComponents = []Component{
+	{Name: "left-pad", Version: "1.3.0", PURL: "pkg:npm/left-pad@1.3.0", License: "MIT"},
+	{Name: "no-license-pkg", Version: "2.0.0", PURL: "pkg:npm/no-license-pkg@2.0.0"},
+}
+
+func init() {
+	ToolVersion = "test"
+}
+
+// compareGolden marshals got (already normalized to strip nondeterministic
+// fields) and compares it against testdata/name, rewriting the golden file
+// when -update is passed.
+func compareGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("failed to update golden file %s: %v", path, err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s: %v", path, err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("%s does not match golden file; rerun with -update if this change is intentional\ngot:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func TestCycloneDXJSONConformsToGolden(t *testing.T) {
+	bom := newCycloneDXBOM("my-app", testComponents)
+
+	// Nondeterministic per run; golden file pins a fixed placeholder instead.
+	bom.SerialNumber = "urn:uuid:00000000-0000-4000-8000-000000000000"
+	bom.Metadata.Timestamp = "2024-01-01T00:00:00Z"
+
+	if bom.BomFormat != "CycloneDX" {
+		t.Errorf("BomFormat = %q, want CycloneDX", bom.BomFormat)
+	}
+	if bom.SpecVersion != "1.5" {
+		t.Errorf("SpecVersion = %q, want 1.5", bom.SpecVersion)
+	}
+	if len(bom.Components) != len(testComponents) {
+		t.Fatalf("got %d components, want %d", len(bom.Components), len(testComponents))
+	}
+	if bom.Components[1].Licenses != nil {
+		t.Errorf("component with no license should omit the licenses field, got %+v", bom.Components[1].Licenses)
+	}
+
+	data, err := json.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent: %v", err)
+	}
+	compareGolden(t, "cyclonedx.json", data)
+}
+
+func TestCycloneDXXMLConformsToGolden(t *testing.T) {
+	bom := newCycloneDXBOMXML("my-app", testComponents)
+
+	bom.SerialNumber = "urn:uuid:00000000-0000-4000-8000-000000000000"
+	bom.Metadata.Timestamp = "2024-01-01T00:00:00Z"
+
+	if bom.Xmlns != "http://cyclonedx.org/schema/bom/1.5" {
+		t.Errorf("Xmlns = %q, want the CycloneDX 1.5 schema URI", bom.Xmlns)
+	}
+	if len(bom.Components.Component) != len(testComponents) {
+		t.Fatalf("got %d components, want %d", len(bom.Components.Component), len(testComponents))
+	}
+
+	data, err := xml.MarshalIndent(bom, "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent: %v", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	compareGolden(t, "cyclonedx.xml", data)
+}
+
+func TestSPDXJSONConformsToGolden(t *testing.T) {
+	doc := newSPDXDocument("my-app", testComponents)
+
+	doc.DocumentNamespace = "https://fossa-nx.local/spdxdocs/my-app-00000000-0000-4000-8000-000000000000"
+	doc.CreationInfo.Created = "2024-01-01T00:00:00Z"
+
+	if doc.SPDXVersion != "SPDX-2.3" {
+		t.Errorf("SPDXVersion = %q, want SPDX-2.3", doc.SPDXVersion)
+	}
+	if len(doc.Packages) != len(testComponents) {
+		t.Fatalf("got %d packages, want %d", len(doc.Packages), len(testComponents))
+	}
+	if doc.Packages[1].LicenseConcluded != "NOASSERTION" {
+		t.Errorf("component with no license should fall back to NOASSERTION, got %q", doc.Packages[1].LicenseConcluded)
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent: %v", err)
+	}
+	compareGolden(t, "spdx.json", data)
+}
+
+func TestMergeComponentsDedupesByPURL(t *testing.T) {
+	merged := MergeComponents(map[string][]Component{
+		"app-a": {{Name: "left-pad", Version: "1.3.0", PURL: "pkg:npm/left-pad@1.3.0"}},
+		"app-b": {{Name: "left-pad", Version: "1.3.0", PURL: "pkg:npm/left-pad@1.3.0"}},
+	})
+
+	if len(merged) != 1 {
+		t.Fatalf("got %d merged components, want 1 (deduped by PURL)", len(merged))
+	}
+}
@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dayNames maps the three-letter day-of-week abbreviations cron expressions
+// commonly use (as in the "0 9 * * MON" example from the schedule config)
+// to their numeric cron value, where Sunday is 0.
+var dayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// cronField is a parsed field of a cron expression: the set of values that
+// satisfy it, stored as a lookup table rather than re-evaluated per match.
+type cronField struct {
+	allowed map[int]bool
+}
+
+func (f cronField) matches(v int) bool {
+	return f.allowed[v]
+}
+
+// CronSchedule is a parsed 5-field cron expression (minute hour
+// day-of-month month day-of-week).
+type CronSchedule struct {
+	expr                                       string
+	minute, hour, dayOfMonth, month, dayOfWeek cronField
+}
+
+// ParseCron parses a standard 5-field cron expression. Each field accepts
+// "*", a single value, a comma-separated list, a range ("1-5"), or a step
+// ("*/15"); the day-of-week field additionally accepts the MON-SUN names.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour dom month dow), got %d", expr, len(fields))
+	}
+
+	minute, err := parseField(fields[0], 0, 59, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cron minute field: %w", err)
+	}
+	hour, err := parseField(fields[1], 0, 23, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cron hour field: %w", err)
+	}
+	dayOfMonth, err := parseField(fields[2], 1, 31, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cron day-of-month field: %w", err)
+	}
+	month, err := parseField(fields[3], 1, 12, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cron month field: %w", err)
+	}
+	dayOfWeek, err := parseField(fields[4], 0, 6, dayNames)
+	if err != nil {
+		return nil, fmt.Errorf("cron day-of-week field: %w", err)
+	}
+
+	return &CronSchedule{
+		expr:       expr,
+		minute:     minute,
+		hour:       hour,
+		dayOfMonth: dayOfMonth,
+		month:      month,
+		dayOfWeek:  dayOfWeek,
+	}, nil
+}
+
+func parseField(raw string, min, max int, names map[string]int) (cronField, error) {
+	allowed := make(map[int]bool)
+
+	for _, part := range strings.Split(raw, ",") {
+		step := 1
+		valueRange := part
+		if i := strings.IndexByte(part, '/'); i != -1 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return cronField{}, fmt.Errorf("invalid step in %q", part)
+			}
+			step = s
+			valueRange = part[:i]
+		}
+
+		lo, hi := min, max
+		switch {
+		case valueRange == "*":
+			// lo/hi already cover the full range
+		case strings.Contains(valueRange, "-"):
+			bounds := strings.SplitN(valueRange, "-", 2)
+			l, err := parseValue(bounds[0], names)
+			if err != nil {
+				return cronField{}, err
+			}
+			h, err := parseValue(bounds[1], names)
+			if err != nil {
+				return cronField{}, err
+			}
+			lo, hi = l, h
+		default:
+			v, err := parseValue(valueRange, names)
+			if err != nil {
+				return cronField{}, err
+			}
+			lo, hi = v, v
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return cronField{}, fmt.Errorf("value out of range [%d-%d] in %q", min, max, part)
+		}
+
+		for v := lo; v <= hi; v += step {
+			allowed[v] = true
+		}
+	}
+
+	return cronField{allowed: allowed}, nil
+}
+
+func parseValue(raw string, names map[string]int) (int, error) {
+	if names != nil {
+		if v, ok := names[strings.ToUpper(raw)]; ok {
+			return v, nil
+		}
+	}
+	return strconv.Atoi(raw)
+}
+
+// Next returns the first time strictly after after that satisfies the
+// schedule, checked minute-by-minute (cron's own granularity) up to two
+// years out before giving up.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+
+	limit := after.AddDate(2, 0, 0)
+	for t.Before(limit) {
+		if s.matches(t) {
+			return t
+		}
+		t = t.Add(time.Minute)
+	}
+
+	// No match found within two years; the expression is likely
+	// unsatisfiable (e.g. day-of-month 31 in February-only months).
+	return time.Time{}
+}
+
+func (s *CronSchedule) matches(t time.Time) bool {
+	return s.minute.matches(t.Minute()) &&
+		s.hour.matches(t.Hour()) &&
+		s.dayOfMonth.matches(t.Day()) &&
+		s.month.matches(int(t.Month())) &&
+		s.dayOfWeek.matches(int(t.Weekday()))
+}
+
+// String returns the original cron expression.
+func (s *CronSchedule) String() string {
+	return s.expr
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// FlattenIssues converts a scan's per-project results into TrackedIssues
+// for diffing and persistence, dropping failed projects since a scan
+// failure isn't a vulnerability to report as new or resolved.
+func FlattenIssues(results []models.Result) []TrackedIssue {
+	var tracked []TrackedIssue
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		for _, issue := range result.Issues {
+			tracked = append(tracked, TrackedIssue{Project: result.Project, VulnerabilityIssue: issue})
+		}
+	}
+	return tracked
+}
+
+// issueKey identifies the same vulnerability across runs: the same
+// project, and the same CVE if one is known, falling back to the
+// vulnerability name since not every advisory has a CVE assigned.
+func issueKey(t TrackedIssue) string {
+	if t.CVE != "" {
+		return fmt.Sprintf("%s\x00%s", t.Project, t.CVE)
+	}
+	return fmt.Sprintf("%s\x00%s", t.Project, t.Name)
+}
+
+// Diff compares previous and current TrackedIssues and reports which
+// vulnerabilities are new since previous, and which have been resolved
+// (present in previous but absent from current).
+func Diff(previous, current []TrackedIssue) (newIssues, resolvedIssues []TrackedIssue) {
+	previousByKey := make(map[string]TrackedIssue, len(previous))
+	for _, issue := range previous {
+		previousByKey[issueKey(issue)] = issue
+	}
+
+	currentByKey := make(map[string]bool, len(current))
+	for _, issue := range current {
+		key := issueKey(issue)
+		currentByKey[key] = true
+		if _, existed := previousByKey[key]; !existed {
+			newIssues = append(newIssues, issue)
+		}
+	}
+
+	for key, issue := range previousByKey {
+		if !currentByKey[key] {
+			resolvedIssues = append(resolvedIssues, issue)
+		}
+	}
+
+	return newIssues, resolvedIssues
+}
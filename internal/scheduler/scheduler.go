@@ -0,0 +1,157 @@
+// Package scheduler runs fossa-nx scans on a cron schedule without relying
+// on an external cron daemon, for the `fossa-nx daemon` subcommand. Each
+// configured Entry persists its last-run state under DefaultStateDir, so a
+// scheduled digest email can report which vulnerabilities are new or
+// resolved since the previous run (see internal/notify/email's
+// SendDigestReport).
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+	"github.com/kamalesh-seervi/fossa-nx/internal/notify/email"
+)
+
+// ScanFunc runs one full scan and returns its per-project results, the same
+// shape the root command produces.
+type ScanFunc func(ctx context.Context) ([]models.Result, error)
+
+// Entry is one configured recurring scan.
+type Entry struct {
+	Name   string
+	Cron   *CronSchedule
+	Window time.Duration
+}
+
+// Scheduler runs Scan on each Entry's cron schedule, diffing each run
+// against the entry's previous state and emailing a digest when EmailConfig
+// is enabled.
+type Scheduler struct {
+	Entries     []Entry
+	Store       *Store
+	Scan        ScanFunc
+	EmailConfig models.EmailConfig
+	Verbose     bool
+}
+
+// New returns a Scheduler with entries parsed from config. It errors
+// eagerly on any entry with an invalid Cron or Window so a typo in
+// fossa-config.yaml is caught at startup instead of silently never firing.
+func New(configEntries []models.ScheduleEntry, store *Store, scan ScanFunc, emailConfig models.EmailConfig, verbose bool) (*Scheduler, error) {
+	entries := make([]Entry, 0, len(configEntries))
+	for _, ce := range configEntries {
+		cronSchedule, err := ParseCron(ce.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", ce.Name, err)
+		}
+
+		window := 7 * 24 * time.Hour
+		if ce.Window != "" {
+			window, err = ParseWindow(ce.Window)
+			if err != nil {
+				return nil, fmt.Errorf("schedule %q: %w", ce.Name, err)
+			}
+		}
+
+		entries = append(entries, Entry{Name: ce.Name, Cron: cronSchedule, Window: window})
+	}
+
+	return &Scheduler{Entries: entries, Store: store, Scan: scan, EmailConfig: emailConfig, Verbose: verbose}, nil
+}
+
+// Run blocks, firing each entry at its next scheduled time, until ctx is
+// canceled. Entries run sequentially as their times come due; a daemon with
+// overlapping schedules should give each its own process if concurrency
+// matters.
+func (s *Scheduler) Run(ctx context.Context) error {
+	if len(s.Entries) == 0 {
+		return fmt.Errorf("no schedule entries configured; add a `schedules:` section to your config file")
+	}
+
+	next := make([]time.Time, len(s.Entries))
+	now := time.Now()
+	for i, entry := range s.Entries {
+		next[i] = entry.Cron.Next(now)
+		log.Printf("scheduler: %q next run at %s", entry.Name, next[i].Format(time.RFC3339))
+	}
+
+	for {
+		soonest := next[0]
+		soonestIdx := 0
+		for i, t := range next {
+			if t.Before(soonest) {
+				soonest = t
+				soonestIdx = i
+			}
+		}
+
+		wait := time.Until(soonest)
+		if wait < 0 {
+			wait = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		entry := s.Entries[soonestIdx]
+		if err := s.runEntry(ctx, entry); err != nil {
+			log.Printf("scheduler: %q run failed: %v", entry.Name, err)
+		}
+
+		next[soonestIdx] = entry.Cron.Next(time.Now())
+		log.Printf("scheduler: %q next run at %s", entry.Name, next[soonestIdx].Format(time.RFC3339))
+	}
+}
+
+func (s *Scheduler) runEntry(ctx context.Context, entry Entry) error {
+	log.Printf("scheduler: running %q", entry.Name)
+
+	results, err := s.Scan(ctx)
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	state, err := s.Store.Load(entry.Name)
+	if err != nil {
+		log.Printf("scheduler: %q: %v; treating as first run", entry.Name, err)
+	}
+
+	// A previous run older than this entry's window is too stale to diff
+	// against meaningfully (e.g. after the daemon was down past the digest
+	// period), so treat it the same as no prior state.
+	previous := state.Issues
+	if !state.LastRun.IsZero() && time.Since(state.LastRun) > entry.Window {
+		previous = nil
+	}
+
+	current := FlattenIssues(results)
+	newIssues, resolvedIssues := Diff(previous, current)
+
+	if s.EmailConfig.Enabled {
+		if err := email.SendDigestReport(results, toFindings(newIssues), toFindings(resolvedIssues), s.EmailConfig, s.Verbose); err != nil {
+			log.Printf("scheduler: %q: failed to send digest email: %v", entry.Name, err)
+		}
+	}
+
+	if err := s.Store.Save(entry.Name, State{LastRun: time.Now(), Issues: current}); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	log.Printf("scheduler: %q complete — %d new, %d resolved since last report", entry.Name, len(newIssues), len(resolvedIssues))
+	return nil
+}
+
+func toFindings(issues []TrackedIssue) []email.Finding {
+	findings := make([]email.Finding, 0, len(issues))
+	for _, issue := range issues {
+		findings = append(findings, email.Finding{Project: issue.Project, VulnerabilityIssue: issue.VulnerabilityIssue})
+	}
+	return findings
+}
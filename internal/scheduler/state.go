@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// TrackedIssue is one project's vulnerability as recorded in a schedule
+// entry's persisted state, so a later run can tell which issues are new or
+// resolved since the last digest.
+type TrackedIssue struct {
+	Project string `json:"project"`
+	models.VulnerabilityIssue
+}
+
+// State is one schedule entry's persisted last-run snapshot.
+type State struct {
+	LastRun time.Time      `json:"lastRun"`
+	Issues  []TrackedIssue `json:"issues"`
+}
+
+// Store persists each schedule entry's State as its own JSON file under
+// dir, mirroring internal/cache's one-file-per-key layout.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a Store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultStateDir returns $XDG_STATE_HOME/fossa-nx, falling back to
+// ~/.local/state/fossa-nx if XDG_STATE_HOME isn't set.
+func DefaultStateDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "fossa-nx")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".fossa-nx/state"
+	}
+	return filepath.Join(home, ".local", "state", "fossa-nx")
+}
+
+// Load returns the persisted state for the schedule entry named name, or a
+// zero-value State (empty Issues, zero LastRun) if none has been recorded
+// yet.
+func (s *Store) Load(name string) (State, error) {
+	data, err := os.ReadFile(s.entryPath(name))
+	if os.IsNotExist(err) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("failed to read scheduler state for %q: %w", name, err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("failed to parse scheduler state for %q: %w", name, err)
+	}
+	return state, nil
+}
+
+// Save persists state for the schedule entry named name.
+func (s *Store) Save(name string, state State) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create scheduler state directory %s: %w", s.dir, err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize scheduler state for %q: %w", name, err)
+	}
+
+	if err := os.WriteFile(s.entryPath(name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write scheduler state for %q: %w", name, err)
+	}
+	return nil
+}
+
+func (s *Store) entryPath(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseWindow parses a digest window like "7d" or "12h". It accepts
+// everything time.ParseDuration does, plus a "d" (day) unit that duration
+// doesn't support, since schedule configs are naturally expressed in days
+// (e.g. a weekly digest's window is "7d").
+func ParseWindow(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q: %w", value, err)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window %q: %w", value, err)
+	}
+	return d, nil
+}
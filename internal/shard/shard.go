@@ -0,0 +1,170 @@
+// Package shard partitions a project list across N parallel CI jobs and
+// merges their results back together, so a monorepo too large for one job's
+// timeout can still run a single logical FOSSA scan.
+package shard
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// History maps a project name to its average scan duration in
+// milliseconds, persisted between runs so Partition can balance shards by
+// actual cost instead of project count alone.
+type History map[string]int64
+
+// LoadHistory reads a History from path. A missing or unreadable file is
+// treated as empty history rather than an error, since the very first run
+// has nothing to load.
+func LoadHistory(path string) History {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return History{}
+	}
+
+	var history History
+	if err := json.Unmarshal(data, &history); err != nil {
+		return History{}
+	}
+	return history
+}
+
+// SaveHistory folds results' durations into the History at path (averaging
+// with whatever was already recorded for a project) and writes it back.
+func SaveHistory(path string, results []models.Result) error {
+	history := LoadHistory(path)
+
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+
+		durationMs := result.Duration.Milliseconds()
+		if existing, ok := history[result.Project]; ok {
+			history[result.Project] = (existing + durationMs) / 2
+		} else {
+			history[result.Project] = durationMs
+		}
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create history directory %s: %w", dir, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize shard history: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write shard history to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Partition assigns projects to shardTotal bins using longest-processing-
+// time-first bin-packing (projects sorted by known duration, each placed in
+// the currently lightest bin) and returns the subset assigned to
+// shardIndex. Projects absent from history are treated as zero-cost; a tie
+// in load goes to the bin holding fewer projects, so with no history at
+// all projects are dealt out round-robin. The partitioning is
+// deterministic given the same projects and history, which is what lets
+// every shard compute its own slice independently.
+func Partition(projects []string, history History, shardIndex, shardTotal int) []string {
+	if shardTotal <= 1 {
+		return projects
+	}
+
+	sorted := make([]string, len(projects))
+	copy(sorted, projects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return history[sorted[i]] > history[sorted[j]]
+	})
+
+	loads := make([]int64, shardTotal)
+	assignments := make([][]string, shardTotal)
+
+	for _, project := range sorted {
+		lightest := 0
+		for i := 1; i < shardTotal; i++ {
+			if loads[i] < loads[lightest] || (loads[i] == loads[lightest] && len(assignments[i]) < len(assignments[lightest])) {
+				lightest = i
+			}
+		}
+		assignments[lightest] = append(assignments[lightest], project)
+		loads[lightest] += history[project]
+	}
+
+	if shardIndex < 0 || shardIndex >= shardTotal {
+		return nil
+	}
+	return assignments[shardIndex]
+}
+
+// Manifest records one shard's assignment and results, written after that
+// shard's scan completes so `merge-shards` can recombine them.
+type Manifest struct {
+	ShardIndex int             `json:"shardIndex"`
+	ShardTotal int             `json:"shardTotal"`
+	Projects   []string        `json:"projects"`
+	Results    []models.Result `json:"results"`
+}
+
+// WriteManifest serializes m to path.
+func WriteManifest(path string, m Manifest) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create manifest directory %s: %w", dir, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize shard manifest: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write shard manifest to %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadManifest reads a single shard's Manifest from path.
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read shard manifest %s: %w", path, err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse shard manifest %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// MergeManifests loads every manifest in paths and concatenates their
+// Results into a single slice, in manifest order.
+func MergeManifests(paths []string) ([]models.Result, error) {
+	var combined []models.Result
+
+	for _, path := range paths {
+		m, err := LoadManifest(path)
+		if err != nil {
+			return nil, err
+		}
+		combined = append(combined, m.Results...)
+	}
+
+	return combined, nil
+}
@@ -0,0 +1,74 @@
+package shard
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamalesh-seervi/fossa-nx/internal/models"
+)
+
+// TestManifestRoundTripsFailedResult guards against a Result whose scan
+// failed breaking WriteManifest/LoadManifest: Result.Error is a bare `error`
+// interface, which used to marshal to `{}` and then fail to unmarshal back,
+// aborting merge-shards for the whole run whenever one shard had a failed
+// project.
+func TestManifestRoundTripsFailedResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.json")
+
+	want := Manifest{
+		ShardIndex: 0,
+		ShardTotal: 2,
+		Projects:   []string{"app-a", "app-b"},
+		Results: []models.Result{
+			{Project: "app-a", DependencyCount: 12},
+			{Project: "app-b", Error: errors.New("fossa analyze: exit status 1")},
+		},
+	}
+
+	if err := WriteManifest(path, want); err != nil {
+		t.Fatalf("WriteManifest: %v", err)
+	}
+
+	got, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+
+	if len(got.Results) != 2 {
+		t.Fatalf("got %d results, want 2", len(got.Results))
+	}
+	if got.Results[0].Error != nil {
+		t.Errorf("app-a Error = %v, want nil", got.Results[0].Error)
+	}
+	if got.Results[1].Error == nil || got.Results[1].Error.Error() != "fossa analyze: exit status 1" {
+		t.Errorf("app-b Error = %v, want %q", got.Results[1].Error, "fossa analyze: exit status 1")
+	}
+}
+
+// TestMergeManifestsRoundTripsFailedResult is the same scenario through
+// MergeManifests, which is what cmd/fossa-nx's merge-shards command calls.
+func TestMergeManifestsRoundTripsFailedResult(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shard-0.json")
+
+	m := Manifest{
+		ShardIndex: 0,
+		ShardTotal: 1,
+		Projects:   []string{"app-a"},
+		Results: []models.Result{
+			{Project: "app-a", Error: errors.New("scan timed out")},
+		},
+	}
+	if err := WriteManifest(path, m); err != nil {
+		t.Fatalf("WriteManifest: %v", err)
+	}
+
+	merged, err := MergeManifests([]string{path})
+	if err != nil {
+		t.Fatalf("MergeManifests: %v", err)
+	}
+	if len(merged) != 1 || merged[0].Error == nil || merged[0].Error.Error() != "scan timed out" {
+		t.Fatalf("merged = %+v, want one result with Error %q", merged, "scan timed out")
+	}
+}